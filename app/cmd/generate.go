@@ -37,6 +37,8 @@ func NewGenerateCommand() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(&builderCfg.GeneratorConfig.WithModel, "with-model", false, "generate a model for the OpenAPI spec, this will generate a model in the output directory with the same name as the OpenAPI spec file, but with a .go extension. This is useful if you want to use the generated code in your own project.")
 	cmd.PersistentFlags().StringVarP(&builderCfg.OutputDirectory, "output", "o", "out", "output directory for generated code - defaults to 'out' in the current directory.")
 	cmd.PersistentFlags().StringVarP(&builderCfg.GeneratorConfig.CommandName, "name", "n", "", "The root command name (and usage), if not provided, it will be set to the info name from the OpenAPI spec. If it is not find, it will be a random name")
+	cmd.PersistentFlags().BoolVar(&builderCfg.GeneratorConfig.DynamicCompletion, "dynamic-completion", false, "complete path parameters in the generated CLI by calling the GET operation of their parent path (e.g. /things for /things/{id}). Parameters with an x-oasnake-completion extension are always completed that way.")
+	cmd.PersistentFlags().BoolVar(&builderCfg.GeneratorConfig.VerbCommands, "verb-commands", false, "generate a subcommand per operation, named after its method or operationId and taking the path parameters as arguments (e.g. devices list, devices get <deviceId>), instead of a command per path selecting the operation with --method.")
 
 	// Compiler flags
 	cmd.PersistentFlags().BoolVar(&builderCfg.CompilerConfig.Compile, "compile", false, "create binary using go compiler. If set to true, it would use by default the go compiler. You can override this by setting either --compile-with-go or --compile-with-docker to true.")
@@ -0,0 +1,329 @@
+package command
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/louislouislouislouis/oasnake/app/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// BodyFlagPrefix is prepended to every request body flag, the same way
+// query and header flags are prefixed.
+const BodyFlagPrefix = "bodyParam-"
+
+// bodyMediaTypes lists the request body media types whose schema is turned
+// into flags, by order of preference.
+var bodyMediaTypes = []string{
+	"application/json",
+	"application/x-www-form-urlencoded",
+	"multipart/form-data",
+}
+
+// BodyParameter is a single primitive property of a request body schema,
+// exposed as one flag in the generated command.
+type BodyParameter struct {
+	FlagValue
+	Path        []string
+	Description string
+	Methods     []Method
+	RequiredFor []Method
+	// File is set for the binary properties of multipart bodies, uploaded from a file
+	File bool
+
+	required bool
+}
+
+// GetName returns the dotted path of the property, e.g. "owner.address.city".
+func (p BodyParameter) GetName() string {
+	return strings.Join(p.Path, ".")
+}
+
+func (p BodyParameter) GetFlagName() string {
+	return BodyFlagPrefix + p.GetName()
+}
+
+func (p BodyParameter) GetSafeDescription() string {
+	return utils.RemoveBackTicks(p.Description)
+}
+
+func (p BodyParameter) IsRequired() bool {
+	return len(p.RequiredFor) > 0
+}
+
+// GetUsageSuffix returns the hints appended to the flag usage, file fields taking a path.
+func (p BodyParameter) GetUsageSuffix() string {
+	if p.File {
+		return " (path of the file to upload)" + p.FlagValue.GetUsageSuffix()
+	}
+	return p.FlagValue.GetUsageSuffix()
+}
+
+// GetGoPath renders the property path as a Go string slice literal.
+func (p BodyParameter) GetGoPath() string {
+	return toGoStringSlice(p.Path)
+}
+
+func (p BodyParameter) GetGoMethods() string {
+	return toGoMethodSlice(p.Methods)
+}
+
+func (p BodyParameter) GetGoRequiredFor() string {
+	return toGoMethodSlice(p.RequiredFor)
+}
+
+// GetBodyParams returns the request body properties of every operation of the
+// node, keyed by dotted path. A property declared by several operations is
+// merged into a single flag.
+func (node *NodeCmd) GetBodyParams() map[string]BodyParameter {
+	params := make(map[string]BodyParameter)
+	for _, method := range node.getSortedMethods() {
+		schema := getBodySchema(node.Methods[method])
+		if schema == nil {
+			continue
+		}
+		multipart := getBodyContentType(node.Methods[method]) == "multipart/form-data"
+		for _, field := range flattenBodySchema(schema, nil, true, nil) {
+			field.File = multipart && field.Type == "string" && field.Format == "binary"
+			name := field.GetName()
+			existing, exists := params[name]
+			if !exists {
+				field.Methods = []Method{method}
+				if field.required {
+					field.RequiredFor = []Method{method}
+				}
+				params[name] = field
+				continue
+			}
+			if existing.FlagValue.GetValueConstructor() != field.FlagValue.GetValueConstructor() {
+				log.Debug().Msgf("Body field %q has different types across methods, keeping the %s one", name, existing.Methods[0])
+			}
+			existing.Methods = append(existing.Methods, method)
+			existing.File = existing.File || field.File
+			if field.required {
+				existing.RequiredFor = append(existing.RequiredFor, method)
+			}
+			params[name] = existing
+		}
+	}
+	return params
+}
+
+func (node *NodeCmd) getSortedMethods() []Method {
+	methods := make([]Method, 0, len(node.Methods))
+	for method := range node.Methods {
+		methods = append(methods, method)
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i] < methods[j] })
+	return methods
+}
+
+// getBodySchema returns the schema of the preferred media type of the
+// operation request body, or nil if the operation has no usable body.
+func getBodySchema(operation *openapi3.Operation) *openapi3.Schema {
+	if operation == nil || operation.RequestBody == nil || operation.RequestBody.Value == nil {
+		return nil
+	}
+	content := operation.RequestBody.Value.Content
+	for _, mediaType := range bodyMediaTypes {
+		if media := content.Get(mediaType); media != nil && media.Schema != nil {
+			return media.Schema.Value
+		}
+	}
+	return nil
+}
+
+// HasRequestBody reports whether one of the operations of the node declares a request body.
+func (node *NodeCmd) HasRequestBody() bool {
+	for _, operation := range node.Methods {
+		if operation != nil && operation.RequestBody != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// GetContentTypes returns the media type of the request body of each operation
+// of the node, sent as its Content-Type.
+func (node *NodeCmd) GetContentTypes() map[Method]string {
+	contentTypes := make(map[Method]string)
+	for method, operation := range node.Methods {
+		if contentType := getBodyContentType(operation); contentType != "" {
+			contentTypes[method] = contentType
+		}
+	}
+	return contentTypes
+}
+
+// GetGoContentTypeCompletions returns the request body media types of the
+// operations of the node as a []string literal, or "" unless one of them
+// declares several media types to choose from with --content-type.
+func (node *NodeCmd) GetGoContentTypeCompletions() string {
+	var mediaTypes []string
+	hasChoice := false
+	for _, method := range node.getSortedMethods() {
+		operation := node.Methods[method]
+		if operation == nil || operation.RequestBody == nil || operation.RequestBody.Value == nil {
+			continue
+		}
+		content := operation.RequestBody.Value.Content
+		hasChoice = hasChoice || len(content) > 1
+		for mediaType := range content {
+			if !slices.Contains(mediaTypes, mediaType) {
+				mediaTypes = append(mediaTypes, mediaType)
+			}
+		}
+	}
+	if !hasChoice {
+		return ""
+	}
+	sort.Strings(mediaTypes)
+	return toGoStringSlice(mediaTypes)
+}
+
+// getBodyContentType returns the preferred media type of the operation request
+// body: the first of bodyMediaTypes declared, or else the first JSON one, or
+// else the first one by name.
+func getBodyContentType(operation *openapi3.Operation) string {
+	if operation == nil || operation.RequestBody == nil || operation.RequestBody.Value == nil {
+		return ""
+	}
+	content := operation.RequestBody.Value.Content
+	for _, mediaType := range bodyMediaTypes {
+		if _, exists := content[mediaType]; exists {
+			return mediaType
+		}
+	}
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+	for _, mediaType := range mediaTypes {
+		if isJSONMediaType(mediaType) {
+			return mediaType
+		}
+	}
+	if len(mediaTypes) > 0 {
+		return mediaTypes[0]
+	}
+	return ""
+}
+
+// flattenBodySchema walks an object schema and returns one BodyParameter per
+// primitive (or array of primitive) property. Nested objects are flattened
+// using their property path. A property is only marked as required when every
+// object on its path is required as well.
+func flattenBodySchema(schema *openapi3.Schema, path []string, required bool, visited []*openapi3.Schema) []BodyParameter {
+	if schema == nil || slices.Contains(visited, schema) {
+		return nil
+	}
+	visited = append(visited, schema)
+
+	properties, requiredProperties := collectProperties(schema)
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []BodyParameter
+	for _, name := range names {
+		ref := properties[name]
+		if ref == nil || ref.Value == nil || ref.Value.ReadOnly {
+			continue
+		}
+		property := ref.Value
+		propertyPath := append(slices.Clone(path), name)
+		propertyRequired := required && slices.Contains(requiredProperties, name)
+
+		if isObjectSchema(property) {
+			fields = append(fields, flattenBodySchema(property, propertyPath, propertyRequired, visited)...)
+			continue
+		}
+
+		value, ok := newFlagValue(property)
+		if !ok {
+			log.Debug().Msgf("Body field %q is not a primitive, it can only be set through --body", strings.Join(propertyPath, "."))
+			continue
+		}
+		// Defaults are left to the server, only the flags set are sent
+		value.Default = nil
+
+		description := property.Description
+		if description == "" && value.IsArray {
+			description = property.Items.Value.Description
+		}
+		fields = append(fields, BodyParameter{
+			FlagValue:   value,
+			Path:        propertyPath,
+			Description: description,
+			required:    propertyRequired,
+		})
+	}
+	return fields
+}
+
+// collectProperties returns the properties and required property names of an
+// object schema, including the ones declared through allOf.
+func collectProperties(schema *openapi3.Schema) (openapi3.Schemas, []string) {
+	properties := make(openapi3.Schemas, len(schema.Properties))
+	for name, property := range schema.Properties {
+		properties[name] = property
+	}
+	required := slices.Clone(schema.Required)
+	for _, sub := range schema.AllOf {
+		if sub == nil || sub.Value == nil {
+			continue
+		}
+		subProperties, subRequired := collectProperties(sub.Value)
+		for name, property := range subProperties {
+			if _, exists := properties[name]; !exists {
+				properties[name] = property
+			}
+		}
+		required = append(required, subRequired...)
+	}
+	return properties, required
+}
+
+func isObjectSchema(schema *openapi3.Schema) bool {
+	if schema.Type.Is("object") {
+		return true
+	}
+	properties, _ := collectProperties(schema)
+	return schema.Type == nil && len(properties) > 0
+}
+
+func getPrimitiveType(schema *openapi3.Schema) string {
+	for _, primitive := range []string{"string", "integer", "number", "boolean"} {
+		if schema.Type.Is(primitive) {
+			return primitive
+		}
+	}
+	return ""
+}
+
+func toGoStringSlice(values []string) string {
+	return "[]string{" + toGoArgs(values) + "}"
+}
+
+// toGoArgs renders values as a comma separated list of Go string literals.
+func toGoArgs(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", value))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func toGoMethodSlice(methods []Method) string {
+	values := make([]string, 0, len(methods))
+	for _, method := range methods {
+		values = append(values, string(method))
+	}
+	return toGoStringSlice(values)
+}
@@ -0,0 +1,182 @@
+package command
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// newTestTree loads the spec and builds its command tree the way the parser does.
+func newTestTree(t *testing.T, spec string) *NodeCmd {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	if err != nil {
+		t.Fatalf("cannot load the spec: %v", err)
+	}
+	root := NewRootNodeCmd()
+	for path, pathItem := range doc.Paths.Map() {
+		current := root
+		for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+			if segment == "" {
+				break
+			}
+			if _, exists := current.Children[segment]; !exists {
+				current.Children[segment] = current.NewChildrenNodeCmd(segment)
+			}
+			current = current.Children[segment]
+		}
+		current.Summary = pathItem.Summary
+		current.Description = pathItem.Description
+		for method, operation := range pathItem.Operations() {
+			parsed, err := ParseMethod(method)
+			if err != nil {
+				t.Fatal(err)
+			}
+			current.Methods[parsed] = operation
+		}
+	}
+	return root
+}
+
+const bodySpec = `
+openapi: 3.0.0
+info: {title: pets, version: "1"}
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              allOf:
+                - $ref: "#/components/schemas/Named"
+                - type: object
+                  required: [owner]
+                  properties:
+                    id: {type: string, readOnly: true}
+                    age: {type: integer, default: 1}
+                    tags:
+                      type: array
+                      items: {type: string, description: a tag}
+                    owner:
+                      type: object
+                      properties:
+                        email: {type: string}
+                        address:
+                          type: object
+                          required: [city]
+                          properties:
+                            city: {type: string}
+                    friends:
+                      type: array
+                      items: {$ref: "#/components/schemas/Named"}
+      responses:
+        "201": {description: created}
+    put:
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              required: [age]
+              properties:
+                age: {type: integer}
+                photo: {type: string, format: binary}
+      responses:
+        "200": {description: ok}
+components:
+  schemas:
+    Named:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string, enum: [rex, felix]}
+`
+
+func TestFlattenBodySchema(t *testing.T) {
+	root := newTestTree(t, bodySpec)
+	schema := getBodySchema(root.Children["pets"].Methods[POST])
+
+	type field struct {
+		name        string
+		value       string
+		description string
+		required    bool
+	}
+	var got []field
+	for _, param := range flattenBodySchema(schema, nil, true, nil) {
+		got = append(got, field{param.GetName(), param.GetValueConstructor(), param.Description, param.required})
+	}
+	want := []field{
+		{"age", "config.NewTypedValue(config.IntegerValue, false)", "", false},
+		{"name", `config.NewTypedValue(config.StringValue, false).WithEnum("rex", "felix")`, "", true},
+		{"owner.address.city", "config.NewTypedValue(config.StringValue, false)", "", false},
+		{"owner.email", "config.NewTypedValue(config.StringValue, false)", "", false},
+		{"tags", "config.NewTypedValue(config.StringValue, true)", "a tag", false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenBodySchema() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestGetBodyParams(t *testing.T) {
+	params := newTestTree(t, bodySpec).Children["pets"].GetBodyParams()
+
+	tests := []struct {
+		name            string
+		wantMethods     []Method
+		wantRequiredFor []Method
+		wantFile        bool
+	}{
+		{name: "age", wantMethods: []Method{POST, PUT}, wantRequiredFor: []Method{PUT}},
+		{name: "name", wantMethods: []Method{POST}, wantRequiredFor: []Method{POST}},
+		{name: "photo", wantMethods: []Method{PUT}, wantFile: true},
+		{name: "owner.email", wantMethods: []Method{POST}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param, exists := params[tt.name]
+			if !exists {
+				t.Fatalf("no body param %q in %v", tt.name, params)
+			}
+			if !reflect.DeepEqual(param.Methods, tt.wantMethods) {
+				t.Errorf("Methods = %v, want %v", param.Methods, tt.wantMethods)
+			}
+			if !reflect.DeepEqual(param.RequiredFor, tt.wantRequiredFor) {
+				t.Errorf("RequiredFor = %v, want %v", param.RequiredFor, tt.wantRequiredFor)
+			}
+			if param.File != tt.wantFile {
+				t.Errorf("File = %t, want %t", param.File, tt.wantFile)
+			}
+		})
+	}
+	if len(params) != 6 {
+		t.Errorf("%d body params, want 6", len(params))
+	}
+}
+
+func TestGetBodyContentType(t *testing.T) {
+	tests := []struct {
+		name       string
+		mediaTypes []string
+		want       string
+	}{
+		{name: "no body"},
+		{name: "preferred media type", mediaTypes: []string{"text/plain", "multipart/form-data", "application/json"}, want: "application/json"},
+		{name: "other JSON media type", mediaTypes: []string{"text/plain", "application/merge-patch+json"}, want: "application/merge-patch+json"},
+		{name: "first by name", mediaTypes: []string{"text/plain", "application/octet-stream"}, want: "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation := openapi3.NewOperation()
+			if tt.mediaTypes != nil {
+				operation.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithContent(openapi3.NewContentWithSchema(nil, tt.mediaTypes))}
+			}
+			if got := getBodyContentType(operation); got != tt.want {
+				t.Errorf("getBodyContentType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package command
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/zerolog/log"
+)
+
+// CompletionExtension is the vendor extension of a path parameter describing
+// the list operation used to complete its values, e.g.
+//
+//	x-oasnake-completion:
+//	  path: /devices
+//	  jsonPath: items[].guid
+//	  descriptionPath: items[].name
+//
+// Setting it to false disables the dynamic completion of the parameter.
+const CompletionExtension = "x-oasnake-completion"
+
+// idFields and descriptionFields are the item properties looked for, by order
+// of preference, when the completion source is guessed from the list response.
+var (
+	idFields          = []string{"id", "guid", "uuid", "key", "name"}
+	descriptionFields = []string{"name", "displayName", "title", "label", "description"}
+)
+
+// CompletionSource describes the list operation called by the generated CLI
+// to complete a path parameter.
+type CompletionSource struct {
+	// Path of the GET list operation, may contain path params
+	Path string
+	// JSONPath selects the values in the list response, e.g. "items[].id"
+	JSONPath string
+	// DescriptionPath selects the descriptions of the values, if any
+	DescriptionPath string
+	// Security holds the requirements of the list operation as a Go literal, if any
+	Security string
+	// Servers holds the servers of the list operation as a Go literal, if it declares its own
+	Servers string
+}
+
+// GetCompletionSource returns the list operation used to complete the path
+// parameter of the node, or nil when its values cannot be completed.
+// The x-oasnake-completion extension of the parameter takes precedence, the
+// source is otherwise guessed from the GET operation of the parent path when
+// the dynamic completion mode is enabled.
+func (node *NodeCmd) GetCompletionSource() *CompletionSource {
+	if !node.IsParam() {
+		return nil
+	}
+
+	if extension, exists := node.GetPathParam().Extensions[CompletionExtension]; exists {
+		source := parseCompletionExtension(extension)
+		if source == nil {
+			return nil
+		}
+		if list := node.getRoot().findNode(source.Path); list != nil {
+			source.Security = list.GetSecurity()[GET]
+			source.Servers = list.GetServers()[GET]
+		}
+		return source
+	}
+
+	if !node.GlobalConfig.DynamicCompletion || node.Parent == nil {
+		return nil
+	}
+	list := node.Parent.Methods[GET]
+	if list == nil {
+		return nil
+	}
+	source := guessCompletionSource(getSuccessJSONSchema(list), node.GetParamName())
+	if source == nil {
+		log.Debug().Msgf("Cannot guess the values of %s from GET %s", node.GetParamName(), node.Parent.GetPath())
+		return nil
+	}
+	source.Path = node.Parent.GetPath()
+	source.Security = node.Parent.GetSecurity()[GET]
+	source.Servers = node.Parent.GetServers()[GET]
+	return source
+}
+
+func parseCompletionExtension(extension any) *CompletionSource {
+	values, ok := extension.(map[string]any)
+	if !ok {
+		return nil
+	}
+	source := &CompletionSource{}
+	source.Path, _ = values["path"].(string)
+	source.JSONPath, _ = values["jsonPath"].(string)
+	source.DescriptionPath, _ = values["descriptionPath"].(string)
+	if source.Path == "" {
+		log.Warn().Msgf("%s extension without path is ignored", CompletionExtension)
+		return nil
+	}
+	return source
+}
+
+// guessCompletionSource looks for the values of a parameter in a list
+// response, being either an array of items or an object wrapping one.
+func guessCompletionSource(schema *openapi3.Schema, paramName string) *CompletionSource {
+	if schema == nil {
+		return nil
+	}
+
+	itemsPath := "[]"
+	items := schema
+	if !schema.Type.Is("array") {
+		properties, _ := collectProperties(schema)
+		names := make([]string, 0, len(properties))
+		for name, property := range properties {
+			if property != nil && property.Value != nil && property.Value.Type.Is("array") {
+				names = append(names, name)
+			}
+		}
+		if len(names) != 1 {
+			return nil
+		}
+		itemsPath = names[0] + "[]"
+		items = properties[names[0]].Value
+	}
+	if items.Items == nil || items.Items.Value == nil {
+		return nil
+	}
+
+	item := items.Items.Value
+	if getPrimitiveType(item) != "" {
+		return &CompletionSource{JSONPath: itemsPath}
+	}
+
+	properties, _ := collectProperties(item)
+	idField := findProperty(properties, append([]string{paramName}, idFields...))
+	if idField == "" {
+		return nil
+	}
+	source := &CompletionSource{JSONPath: itemsPath + "." + idField}
+	if descriptionField := findProperty(properties, descriptionFields); descriptionField != "" && descriptionField != idField {
+		source.DescriptionPath = itemsPath + "." + descriptionField
+	}
+	return source
+}
+
+func findProperty(properties openapi3.Schemas, candidates []string) string {
+	for _, candidate := range candidates {
+		if properties[candidate] != nil {
+			return candidate
+		}
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+const completionSpec = `
+openapi: 3.0.0
+info: {title: devices, version: "1"}
+paths:
+  /devices:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  total: {type: integer}
+                  items:
+                    type: array
+                    items:
+                      type: object
+                      properties:
+                        guid: {type: string}
+                        name: {type: string}
+  /devices/{deviceId}:
+    get:
+      parameters:
+        - {name: deviceId, in: path, required: true, schema: {type: string}}
+      responses:
+        "200": {description: ok}
+  /tags:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {type: array, items: {type: string}}
+  /tags/{tag}:
+    delete:
+      parameters:
+        - {name: tag, in: path, required: true, schema: {type: string}}
+      responses:
+        "204": {description: deleted}
+  /owners:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    ownerId: {type: integer}
+                    id: {type: integer}
+  /owners/{ownerId}:
+    get:
+      parameters:
+        - {name: ownerId, in: path, required: true, schema: {type: integer}}
+      responses:
+        "200": {description: ok}
+  /boxes/{boxId}:
+    get:
+      parameters:
+        - {name: boxId, in: path, required: true, schema: {type: string}}
+      responses:
+        "200": {description: ok}
+  /stores/{storeId}:
+    get:
+      parameters:
+        - name: storeId
+          in: path
+          required: true
+          schema: {type: string}
+          x-oasnake-completion: {path: /devices, jsonPath: "items[].guid"}
+      responses:
+        "200": {description: ok}
+  /stores/{storeId}/items/{itemId}:
+    get:
+      parameters:
+        - {name: storeId, in: path, required: true, schema: {type: string}}
+        - name: itemId
+          in: path
+          required: true
+          schema: {type: string}
+          x-oasnake-completion: false
+      responses:
+        "200": {description: ok}
+`
+
+func TestGetCompletionSource(t *testing.T) {
+	tests := []struct {
+		name              string
+		path              string
+		dynamicCompletion bool
+		want              *CompletionSource
+	}{
+		{
+			name:              "items wrapped in an object",
+			path:              "/devices/{deviceId}",
+			dynamicCompletion: true,
+			want:              &CompletionSource{Path: "/devices", JSONPath: "items[].guid", DescriptionPath: "items[].name"},
+		},
+		{
+			name:              "array of primitives",
+			path:              "/tags/{tag}",
+			dynamicCompletion: true,
+			want:              &CompletionSource{Path: "/tags", JSONPath: "[]"},
+		},
+		{
+			name:              "property named after the param first",
+			path:              "/owners/{ownerId}",
+			dynamicCompletion: true,
+			want:              &CompletionSource{Path: "/owners", JSONPath: "[].ownerId"},
+		},
+		{
+			name: "guessed only in dynamic completion mode",
+			path: "/devices/{deviceId}",
+		},
+		{
+			name:              "parent without list operation",
+			path:              "/boxes/{boxId}",
+			dynamicCompletion: true,
+		},
+		{
+			name: "extension",
+			path: "/stores/{storeId}",
+			want: &CompletionSource{Path: "/devices", JSONPath: "items[].guid"},
+		},
+		{
+			name:              "extension disabling the completion",
+			path:              "/stores/{storeId}/items/{itemId}",
+			dynamicCompletion: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newTestTree(t, completionSpec)
+			root.SetGlobalConfig(CommandGlobalConfig{DynamicCompletion: tt.dynamicCompletion})
+			node := root.findNode(tt.path)
+			if node == nil {
+				t.Fatalf("no node for %s", tt.path)
+			}
+			got := node.GetCompletionSource()
+			if got != nil {
+				got.Security, got.Servers = "", ""
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCompletionSource() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,13 +8,20 @@ import (
 type Method string
 
 const (
-	GET    Method = "GET"
-	POST   Method = "POST"
-	PUT    Method = "PUT"
-	DELETE Method = "DELETE"
-	PATCH  Method = "PATCH"
+	GET     Method = "GET"
+	POST    Method = "POST"
+	PUT     Method = "PUT"
+	DELETE  Method = "DELETE"
+	PATCH   Method = "PATCH"
+	HEAD    Method = "HEAD"
+	OPTIONS Method = "OPTIONS"
+	TRACE   Method = "TRACE"
 )
 
+// defaultMethods lists the methods by order of preference for the default
+// method of a command, the diagnostic ones coming last.
+var defaultMethods = []Method{GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS, TRACE}
+
 func ParseMethod(s string) (Method, error) {
 	upper := strings.ToUpper(s)
 	switch upper {
@@ -28,6 +35,12 @@ func ParseMethod(s string) (Method, error) {
 		return DELETE, nil
 	case string(PATCH):
 		return PATCH, nil
+	case string(HEAD):
+		return HEAD, nil
+	case string(OPTIONS):
+		return OPTIONS, nil
+	case string(TRACE):
+		return TRACE, nil
 	default:
 		return "", fmt.Errorf("unknown method: %s", s)
 	}
@@ -0,0 +1,28 @@
+package command
+
+import "testing"
+
+func TestGetDefaultMethod(t *testing.T) {
+	tests := []struct {
+		name    string
+		methods []Method
+		want    Method
+	}{
+		{name: "no operation", want: GET},
+		{name: "GET first", methods: []Method{TRACE, DELETE, GET, POST}, want: GET},
+		{name: "POST before the others", methods: []Method{DELETE, PATCH, POST, PUT}, want: POST},
+		{name: "diagnostic methods last", methods: []Method{TRACE, OPTIONS, HEAD, DELETE}, want: DELETE},
+		{name: "only diagnostic methods", methods: []Method{TRACE, OPTIONS}, want: OPTIONS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := NewRootNodeCmd()
+			for _, method := range tt.methods {
+				node.Methods[method] = nil
+			}
+			if got := node.GetDefaultMethod(); got != tt.want {
+				t.Errorf("GetDefaultMethod() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
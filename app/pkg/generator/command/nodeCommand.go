@@ -19,6 +19,26 @@ type CommandGlobalConfig struct {
 	ConfigPath  string
 	AppPath     string
 	ServicePath string
+	// DynamicCompletion enables the completion of path params from the list operation of their parent path
+	DynamicCompletion bool
+	// SecuritySchemes are the supported security schemes of the spec
+	SecuritySchemes []SecurityScheme
+	// Security holds the requirements of the spec, applying to operations not declaring their own
+	Security openapi3.SecurityRequirements
+	// Servers are the servers of the spec, BaseUrl replacing them when set
+	Servers []Server
+	// Requires are the modules required by the generated go.mod
+	Requires []Module
+	// Tags are the tags of the spec, describing the resources of the verb layout
+	Tags openapi3.Tags
+}
+
+// Module is a module required by the generated code, at a given version.
+type Module struct {
+	Path    string
+	Version string
+	// Purpose is written as the comment of its require directive
+	Purpose string
 }
 
 var CommonFolder = "common"
@@ -66,11 +86,19 @@ type NodeCmd struct {
 	segment      string
 	Methods      map[Method]*openapi3.Operation
 	Children     map[string]*NodeCmd
-	depth        int
-	paramDepth   int
+	// Summary and Description are the ones of the path item of the node
+	Summary     string
+	Description string
+	depth       int
+	paramDepth  int
+	// pathNode is the node of the path of the operation of a verb command, see NewVerbTree
+	pathNode *NodeCmd
 }
 
 func (node *NodeCmd) GetPath() string {
+	if node.IsVerb() {
+		return node.pathNode.GetPath()
+	}
 	if node.IsRootNodeCmd() {
 		return "/"
 	}
@@ -85,15 +113,15 @@ func (node *NodeCmd) GetPath() string {
 	return "/" + strings.Join(segments, "/")
 }
 
+// GetDefaultMethod returns the first method of the node by order of
+// preference, see defaultMethods, or GET when it has no operation.
 func (node *NodeCmd) GetDefaultMethod() Method {
-	defaultMethod := GET
-	if _, ok := node.Methods[GET]; !ok {
-		for m := range node.Methods {
-			defaultMethod = m
-			break
+	for _, method := range defaultMethods {
+		if _, ok := node.Methods[method]; ok {
+			return method
 		}
 	}
-	return defaultMethod
+	return GET
 }
 
 func (node *NodeCmd) GetAppModule() string {
@@ -124,21 +152,72 @@ func (node *NodeCmd) GetHeaderParams() map[string]Parameter {
 	return node.getParams("header")
 }
 
+// getParams returns the parameters of the given location declared by the
+// operations of the node. Operations are expected to already hold the
+// parameters of their path item, see parser.mergePathItemParameters.
 func (node *NodeCmd) getParams(paramType string) map[string]Parameter {
 	params := make(map[string]Parameter)
-	for _, operation := range node.Methods {
-		for _, item := range operation.Parameters {
-			if v := item.Value; v != nil {
-				if v.In == paramType {
-					params[v.Name] = Parameter{*v}
-				}
+	for _, method := range node.getSortedMethods() {
+		for _, item := range node.Methods[method].Parameters {
+			v := item.Value
+			if v == nil || v.In != paramType {
+				continue
+			}
+			param, exists := params[v.Name]
+			if !exists {
+				param = *NewParameter(*v)
+			}
+			if param.Description == "" {
+				param.Description = v.Description
 			}
+			param.Methods = append(param.Methods, method)
+			if v.Required {
+				param.RequiredFor = append(param.RequiredFor, method)
+			}
+			params[v.Name] = param
 		}
 	}
 	return params
 }
 
+// GetPathParam returns the path parameter the node stands for, as declared by
+// its own operations or, failing that, by the operations of its descendants.
+func (node *NodeCmd) GetPathParam() Parameter {
+	name := node.GetParamName()
+	if param, found := node.findPathParam(name); found {
+		return param
+	}
+	return *NewParameter(openapi3.Parameter{Name: name, In: openapi3.ParameterInPath})
+}
+
+func (node *NodeCmd) findPathParam(name string) (Parameter, bool) {
+	if param, exists := node.getParams("path")[name]; exists {
+		return param, true
+	}
+	for _, segment := range node.getSortedSegments() {
+		if param, found := node.Children[segment].findPathParam(name); found {
+			return param, true
+		}
+	}
+	return Parameter{}, false
+}
+
 func (node *NodeCmd) getCmdDescription(isShort bool) string {
+	// A verb command describes its only operation
+	if node.IsVerb() {
+		operation := node.getOperation()
+		if isShort || operation.Description == "" {
+			if operation.Summary == "" {
+				return string(node.GetDefaultMethod()) + " " + node.GetPath()
+			}
+			return utils.RemoveBackTicks(operation.Summary)
+		}
+		return utils.RemoveBackTicks(operation.Description)
+	}
+	if len(node.Methods) == 0 && !node.IsRootNodeCmd() && node.hasVerbs() {
+		return node.getResourceDescription(isShort)
+	}
+
 	var builder strings.Builder
 	for method, operation := range node.Methods {
 		description := ""
@@ -156,6 +235,28 @@ func (node *NodeCmd) getCmdDescription(isShort bool) string {
 	return builder.String()
 }
 
+// getResourceDescription describes a resource of the verb layout with the
+// description of its path, or else of the tag of all its verbs, the first line
+// being the short one.
+func (node *NodeCmd) getResourceDescription(isShort bool) string {
+	description := node.Description
+	if description == "" || (isShort && node.Summary != "") {
+		description = node.Summary
+	}
+	if description == "" {
+		if tag := node.GlobalConfig.Tags.Get(node.getVerbsTag()); tag != nil {
+			description = tag.Description
+		}
+	}
+	if description == "" {
+		description = "Operations on " + node.segment
+	}
+	if isShort {
+		description, _, _ = strings.Cut(strings.TrimSpace(description), "\n")
+	}
+	return utils.RemoveBackTicks(strings.TrimSpace(description))
+}
+
 func (node *NodeCmd) GetFileName() string {
 	if node.IsRootNodeCmd() {
 		return "root.go"
@@ -214,6 +315,13 @@ func (node *NodeCmd) GetUsage() string {
 	if node.IsParam() {
 		return "<" + strings.Trim(node.segment, "{}") + ">"
 	}
+	if node.IsVerb() {
+		usage := node.segment
+		for _, arg := range node.GetPathArgs() {
+			usage += " <" + arg.GetParamName() + ">"
+		}
+		return usage
+	}
 	return node.GetPackageName()
 }
 
@@ -230,6 +338,28 @@ func (node *NodeCmd) GetCobraFunctionCommandName() string {
 	return utils.GoCodeString(commandName)
 }
 
+func (node *NodeCmd) getRoot() *NodeCmd {
+	root := node
+	for !root.IsRootNodeCmd() {
+		root = root.Parent
+	}
+	return root
+}
+
+// findNode returns the node of the given path, relative to node, or nil if it does not exist.
+func (node *NodeCmd) findNode(nodePath string) *NodeCmd {
+	current := node
+	for _, segment := range strings.Split(strings.Trim(nodePath, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		if current = current.Children[segment]; current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
 func (node *NodeCmd) IsRootNodeCmd() bool {
 	return node.Parent == nil
 }
@@ -0,0 +1,85 @@
+package command
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// maxDefaultColumns bounds the columns of the table output guessed from the response schema
+const maxDefaultColumns = 6
+
+// GetDefaultColumns returns the table columns of each operation of the node
+// whose success response schema has scalar properties, as []string literals.
+func (node *NodeCmd) GetDefaultColumns() map[Method]string {
+	columns := make(map[Method]string)
+	for method, operation := range node.Methods {
+		if names := guessColumns(getSuccessJSONSchema(operation)); len(names) > 0 {
+			columns[method] = toGoStringSlice(names)
+		}
+	}
+	return columns
+}
+
+// guessColumns returns the scalar properties of the rows of a response, being
+// the items of an array, of an object wrapping one, or else the object itself.
+// Identifier and name properties come first, then the others by name.
+func guessColumns(schema *openapi3.Schema) []string {
+	if schema == nil {
+		return nil
+	}
+
+	row := schema
+	if items := getListItems(schema); items != nil {
+		row = items
+	}
+	properties, _ := collectProperties(row)
+
+	first := []string{}
+	for _, candidate := range append(append([]string{}, idFields...), descriptionFields...) {
+		if property := properties[candidate]; property != nil && property.Value != nil && getPrimitiveType(property.Value) != "" && !slices.Contains(first, candidate) {
+			first = append(first, candidate)
+		}
+	}
+	others := []string{}
+	for name, property := range properties {
+		if property != nil && property.Value != nil && getPrimitiveType(property.Value) != "" && !slices.Contains(first, name) {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+
+	columns := append(first, others...)
+	if len(columns) > maxDefaultColumns {
+		columns = columns[:maxDefaultColumns]
+	}
+	return columns
+}
+
+// getListItems returns the schema of the items of a list response, being an
+// array or an object with a single array property, nil otherwise.
+func getListItems(schema *openapi3.Schema) *openapi3.Schema {
+	list := schema
+	if !schema.Type.Is("array") {
+		properties, _ := collectProperties(schema)
+		var array *openapi3.Schema
+		for _, property := range properties {
+			if property == nil || property.Value == nil || !property.Value.Type.Is("array") {
+				continue
+			}
+			if array != nil {
+				return nil
+			}
+			array = property.Value
+		}
+		if array == nil {
+			return nil
+		}
+		list = array
+	}
+	if list.Items == nil || list.Items.Value == nil {
+		return nil
+	}
+	return list.Items.Value
+}
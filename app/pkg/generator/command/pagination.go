@@ -0,0 +1,274 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// PaginationExtension is the vendor extension of an operation describing how
+// its pages are fetched by --all, e.g.
+//
+//	x-oasnake-pagination:
+//	  strategy: cursor
+//	  itemsPath: data.items
+//	  cursorParam: after
+//	  cursorPath: meta.nextCursor
+//
+// The strategy is one of page, offset, cursor or link. Setting the extension
+// to false disables the pagination of the operation.
+const PaginationExtension = "x-oasnake-pagination"
+
+// Pagination strategies
+const (
+	PagePagination   = "page"
+	OffsetPagination = "offset"
+	CursorPagination = "cursor"
+	LinkPagination   = "link"
+)
+
+// pageParams, sizeParams, offsetParams, limitParams and cursorParams are the query params
+// looked for, by order of preference, when the pagination is guessed.
+// nextCursorFields are the response properties holding the next cursor.
+var (
+	pageParams       = []string{"page", "pageNumber", "page_number", "pageNo"}
+	sizeParams       = []string{"size", "pageSize", "page_size", "per_page", "perPage", "limit", "count"}
+	offsetParams     = []string{"offset", "skip"}
+	limitParams      = []string{"limit", "size", "count", "pageSize", "page_size"}
+	cursorParams     = []string{"cursor", "pageToken", "page_token", "nextToken", "next_token", "continuationToken", "after", "starting_after"}
+	nextCursorFields = []string{"nextCursor", "next_cursor", "nextPageToken", "next_page_token", "nextToken", "next_token", "continuationToken", "cursor", "next"}
+	// cursorWrappers are the objects holding the next cursor, e.g. "meta.next_cursor"
+	cursorWrappers = []string{"meta", "pagination", "paging", "page", "links", "_links"}
+)
+
+// Pagination describes how the generated CLI fetches the pages of a list operation.
+type Pagination struct {
+	Strategy string
+	// ItemsPath is the dotted path of the array of items in the response, empty if the response is the array
+	ItemsPath string
+	// PageParam is the page number of the page strategy, starting at StartPage
+	PageParam string
+	StartPage int
+	// SizeParam is the page size of the page strategy or the limit of the offset strategy
+	SizeParam   string
+	OffsetParam string
+	// CursorParam receives the cursor found at CursorPath in the response
+	CursorParam string
+	CursorPath  string
+}
+
+// HasPagination reports whether an operation of the node is paginated.
+func (node *NodeCmd) HasPagination() bool {
+	return len(node.GetPagination()) > 0
+}
+
+// GetPagination returns the pagination of each paginated operation of the
+// node, as *config.Pagination literals.
+// The x-oasnake-pagination extension takes precedence, the pagination of GET
+// operations listing items is otherwise guessed from their query params,
+// their response schema and their Link response header.
+func (node *NodeCmd) GetPagination() map[Method]string {
+	paginations := make(map[Method]string)
+	for method, operation := range node.Methods {
+		var pagination *Pagination
+		if extension, exists := operation.Extensions[PaginationExtension]; exists {
+			pagination = parsePaginationExtension(extension, operation)
+		} else if method == GET {
+			pagination = guessPagination(operation)
+		}
+		if pagination != nil {
+			paginations[method] = pagination.toGo()
+		}
+	}
+	return paginations
+}
+
+func parsePaginationExtension(extension any, operation *openapi3.Operation) *Pagination {
+	values, ok := extension.(map[string]any)
+	if !ok {
+		return nil
+	}
+	pagination := &Pagination{StartPage: 1}
+	pagination.Strategy, _ = values["strategy"].(string)
+	pagination.PageParam, _ = values["pageParam"].(string)
+	pagination.SizeParam, _ = values["sizeParam"].(string)
+	pagination.OffsetParam, _ = values["offsetParam"].(string)
+	pagination.CursorParam, _ = values["cursorParam"].(string)
+	pagination.CursorPath, _ = values["cursorPath"].(string)
+	if startPage, ok := values["startPage"].(float64); ok {
+		pagination.StartPage = int(startPage)
+	}
+	if itemsPath, ok := values["itemsPath"].(string); ok {
+		pagination.ItemsPath = itemsPath
+	} else if schema := getSuccessJSONSchema(operation); schema != nil {
+		pagination.ItemsPath, _ = getListItemsPath(schema)
+	}
+
+	switch pagination.Strategy {
+	case PagePagination:
+		if pagination.PageParam == "" {
+			pagination.PageParam = "page"
+		}
+	case OffsetPagination:
+		if pagination.OffsetParam == "" {
+			pagination.OffsetParam = "offset"
+		}
+	case CursorPagination:
+		if pagination.CursorParam == "" || pagination.CursorPath == "" {
+			return nil
+		}
+	case LinkPagination:
+	default:
+		return nil
+	}
+	return pagination
+}
+
+// guessPagination detects the strategy of a list operation, by order of
+// preference a Link header, a cursor, an offset or a page number.
+func guessPagination(operation *openapi3.Operation) *Pagination {
+	schema := getSuccessJSONSchema(operation)
+	if schema == nil {
+		return nil
+	}
+	itemsPath, isList := getListItemsPath(schema)
+	if !isList {
+		return nil
+	}
+	pagination := &Pagination{ItemsPath: itemsPath, StartPage: 1}
+
+	if response := getSuccessResponse(operation); response != nil {
+		for name := range response.Headers {
+			if strings.EqualFold(name, "Link") {
+				pagination.Strategy = LinkPagination
+				return pagination
+			}
+		}
+	}
+
+	params := getQueryParamSchemas(operation)
+	if cursor := findParam(params, cursorParams, false); cursor != "" {
+		if cursorPath := findNextCursorPath(schema); cursorPath != "" {
+			pagination.Strategy = CursorPagination
+			pagination.CursorParam = cursor
+			pagination.CursorPath = cursorPath
+			return pagination
+		}
+	}
+	if offset := findParam(params, offsetParams, true); offset != "" {
+		pagination.Strategy = OffsetPagination
+		pagination.OffsetParam = offset
+		pagination.SizeParam = findParam(params, limitParams, true)
+		return pagination
+	}
+	if page := findParam(params, pageParams, true); page != "" {
+		pagination.Strategy = PagePagination
+		pagination.PageParam = page
+		pagination.SizeParam = findParam(params, sizeParams, true)
+		if param := params[page]; param != nil {
+			if start, ok := param.Default.(float64); ok {
+				pagination.StartPage = int(start)
+			} else if param.Min != nil {
+				pagination.StartPage = int(*param.Min)
+			}
+		}
+		return pagination
+	}
+	return nil
+}
+
+// getListItemsPath returns the path of the array of items of a list response:
+// empty for an array, the name of the property of an object with a single array property.
+func getListItemsPath(schema *openapi3.Schema) (string, bool) {
+	if schema.Type.Is("array") {
+		return "", true
+	}
+	properties, _ := collectProperties(schema)
+	path := ""
+	for name, property := range properties {
+		if property == nil || property.Value == nil || !property.Value.Type.Is("array") {
+			continue
+		}
+		if path != "" {
+			return "", false
+		}
+		path = name
+	}
+	return path, path != ""
+}
+
+// getQueryParamSchemas returns the schema of the query params of the operation, by name.
+func getQueryParamSchemas(operation *openapi3.Operation) map[string]*openapi3.Schema {
+	params := make(map[string]*openapi3.Schema)
+	for _, item := range operation.Parameters {
+		if item == nil || item.Value == nil || item.Value.In != "query" {
+			continue
+		}
+		var schema *openapi3.Schema
+		if item.Value.Schema != nil {
+			schema = item.Value.Schema.Value
+		}
+		params[item.Value.Name] = schema
+	}
+	return params
+}
+
+// findParam returns the first candidate declared by the params, ignoring the
+// case, numeric params being integers or numbers when their schema is known.
+func findParam(params map[string]*openapi3.Schema, candidates []string, numeric bool) string {
+	for _, candidate := range candidates {
+		for name, schema := range params {
+			if numeric && schema != nil && schema.Type != nil && !schema.Type.Is("integer") && !schema.Type.Is("number") {
+				continue
+			}
+			if strings.EqualFold(name, candidate) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// findNextCursorPath looks for the string property holding the next cursor,
+// at the top level of the response or in one of the cursorWrappers.
+func findNextCursorPath(schema *openapi3.Schema) string {
+	properties, _ := collectProperties(schema)
+	if field := findStringProperty(properties, nextCursorFields); field != "" {
+		return field
+	}
+	for _, wrapper := range cursorWrappers {
+		property := properties[wrapper]
+		if property == nil || property.Value == nil {
+			continue
+		}
+		nested, _ := collectProperties(property.Value)
+		if field := findStringProperty(nested, nextCursorFields); field != "" {
+			return wrapper + "." + field
+		}
+	}
+	return ""
+}
+
+func findStringProperty(properties openapi3.Schemas, candidates []string) string {
+	for _, candidate := range candidates {
+		if property := properties[candidate]; property != nil && property.Value != nil && property.Value.Type.Is("string") {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func (pagination *Pagination) toGo() string {
+	return fmt.Sprintf(
+		"&config.Pagination{Strategy: %q, ItemsPath: %q, PageParam: %q, StartPage: %d, SizeParam: %q, OffsetParam: %q, CursorParam: %q, CursorPath: %q}",
+		pagination.Strategy,
+		pagination.ItemsPath,
+		pagination.PageParam,
+		pagination.StartPage,
+		pagination.SizeParam,
+		pagination.OffsetParam,
+		pagination.CursorParam,
+		pagination.CursorPath,
+	)
+}
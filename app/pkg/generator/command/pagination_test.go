@@ -0,0 +1,196 @@
+package command
+
+import "testing"
+
+const paginationSpec = `
+openapi: 3.0.0
+info: {title: pages, version: "1"}
+paths:
+  /links:
+    get:
+      parameters:
+        - {name: page, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          headers:
+            Link: {schema: {type: string}}
+          content:
+            application/json:
+              schema: {type: array, items: {type: string}}
+  /cursors:
+    get:
+      parameters:
+        - {name: pageToken, in: query, schema: {type: string}}
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data: {type: array, items: {type: object}}
+                  meta:
+                    type: object
+                    properties:
+                      next_page_token: {type: string}
+  /offsets:
+    get:
+      parameters:
+        - {name: Skip, in: query, schema: {type: integer}}
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {type: array, items: {type: object}}
+  /pages:
+    get:
+      parameters:
+        - {name: pageNumber, in: query, schema: {type: integer, minimum: 0}}
+        - {name: per_page, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  total: {type: integer}
+                  results: {type: array, items: {type: object}}
+  /pages-with-default:
+    get:
+      parameters:
+        - {name: page, in: query, schema: {type: integer, default: 0, minimum: 0}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {type: array, items: {type: object}}
+  /cursor-without-next:
+    get:
+      parameters:
+        - {name: cursor, in: query, schema: {type: string}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {type: array, items: {type: object}}
+  /string-page:
+    get:
+      parameters:
+        - {name: page, in: query, schema: {type: string}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {type: array, items: {type: object}}
+  /not-a-list:
+    get:
+      parameters:
+        - {name: page, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  a: {type: array, items: {type: object}}
+                  b: {type: array, items: {type: object}}
+  /extension:
+    get:
+      x-oasnake-pagination: {strategy: cursor, itemsPath: data.items, cursorParam: after, cursorPath: meta.end}
+      responses:
+        "200": {description: ok}
+    post:
+      x-oasnake-pagination: {strategy: page, startPage: 0}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  items: {type: array, items: {type: object}}
+  /disabled:
+    get:
+      x-oasnake-pagination: false
+      parameters:
+        - {name: page, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {type: array, items: {type: object}}
+  /invalid-extension:
+    get:
+      x-oasnake-pagination: {strategy: cursor, cursorParam: after}
+      responses:
+        "200": {description: ok}
+`
+
+func TestGetPagination(t *testing.T) {
+	tests := []struct {
+		path   string
+		method Method
+		want   *Pagination
+	}{
+		{path: "/links", method: GET, want: &Pagination{Strategy: LinkPagination, StartPage: 1}},
+		{path: "/cursors", method: GET, want: &Pagination{Strategy: CursorPagination, ItemsPath: "data", StartPage: 1, CursorParam: "pageToken", CursorPath: "meta.next_page_token"}},
+		{path: "/offsets", method: GET, want: &Pagination{Strategy: OffsetPagination, StartPage: 1, OffsetParam: "Skip", SizeParam: "limit"}},
+		{path: "/pages", method: GET, want: &Pagination{Strategy: PagePagination, ItemsPath: "results", PageParam: "pageNumber", StartPage: 0, SizeParam: "per_page"}},
+		{path: "/pages-with-default", method: GET, want: &Pagination{Strategy: PagePagination, PageParam: "page", StartPage: 0}},
+		{path: "/cursor-without-next", method: GET},
+		{path: "/string-page", method: GET},
+		{path: "/not-a-list", method: GET},
+		{path: "/extension", method: GET, want: &Pagination{Strategy: CursorPagination, ItemsPath: "data.items", StartPage: 1, CursorParam: "after", CursorPath: "meta.end"}},
+		{path: "/extension", method: POST, want: &Pagination{Strategy: PagePagination, ItemsPath: "items", PageParam: "page", StartPage: 0}},
+		{path: "/disabled", method: GET},
+		{path: "/invalid-extension", method: GET},
+	}
+	root := newTestTree(t, paginationSpec)
+	for _, tt := range tests {
+		t.Run(string(tt.method)+" "+tt.path, func(t *testing.T) {
+			want := ""
+			if tt.want != nil {
+				want = tt.want.toGo()
+			}
+			if got := root.findNode(tt.path).GetPagination()[tt.method]; got != want {
+				t.Errorf("GetPagination()[%s] =\n%s\nwant\n%s", tt.method, got, want)
+			}
+		})
+	}
+}
+
+func TestGetListItemsPath(t *testing.T) {
+	root := newTestTree(t, paginationSpec)
+	tests := []struct {
+		path       string
+		wantPath   string
+		wantIsList bool
+	}{
+		{path: "/links", wantPath: "", wantIsList: true},
+		{path: "/pages", wantPath: "results", wantIsList: true},
+		{path: "/not-a-list", wantPath: "", wantIsList: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			schema := getSuccessJSONSchema(root.findNode(tt.path).Methods[GET])
+			path, isList := getListItemsPath(schema)
+			if path != tt.wantPath || isList != tt.wantIsList {
+				t.Errorf("getListItemsPath() = %q, %t, want %q, %t", path, isList, tt.wantPath, tt.wantIsList)
+			}
+		})
+	}
+}
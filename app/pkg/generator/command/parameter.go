@@ -1,20 +1,127 @@
 package command
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/louislouislouislouis/oasnake/app/pkg/utils"
 )
 
 type Parameter struct {
 	openapi3.Parameter
+	FlagValue
+	// Methods lists the operations of the command declaring the parameter
+	Methods []Method
+	// RequiredFor lists the operations of the command requiring the parameter
+	RequiredFor []Method
+	// JSONContent is set for the parameters declared with a JSON content
+	// instead of a schema, their value being sent JSON encoded
+	JSONContent bool
 }
 
 func NewParameter(param openapi3.Parameter) *Parameter {
+	schema, jsonContent := getParamSchema(param)
+	value, ok := newFlagValue(schema)
+	if jsonContent && (!ok || value.IsArray || value.IsObject) {
+		// Structured values are given as JSON documents
+		value = FlagValue{Type: JSONType}
+	}
 	return &Parameter{
-		param,
+		Parameter:   param,
+		FlagValue:   value,
+		JSONContent: jsonContent,
+	}
+}
+
+// getParamSchema returns the schema of the parameter, or else the schema of
+// its content when it is JSON, in which case jsonContent is true.
+func getParamSchema(param openapi3.Parameter) (schema *openapi3.Schema, jsonContent bool) {
+	if param.Schema != nil {
+		return param.Schema.Value, false
+	}
+	for mediaType, media := range param.Content {
+		if !isJSONMediaType(mediaType) {
+			continue
+		}
+		if media != nil && media.Schema != nil {
+			return media.Schema.Value, true
+		}
+		return nil, true
 	}
+	return nil, false
+}
+
+// GetStyle returns the serialization style of the parameter, defaulting to the
+// one defined by the OpenAPI specification for its location.
+func (p Parameter) GetStyle() string {
+	if p.Style != "" {
+		return p.Style
+	}
+	if p.In == openapi3.ParameterInQuery || p.In == openapi3.ParameterInCookie {
+		return openapi3.SerializationForm
+	}
+	return openapi3.SerializationSimple
+}
+
+// GetExplode returns the explode flag of the parameter, which defaults to
+// true for the form style only.
+func (p Parameter) GetExplode() bool {
+	if p.Explode != nil {
+		return *p.Explode
+	}
+	return p.GetStyle() == openapi3.SerializationForm
+}
+
+// GetParamConstructor renders the Go expression building the config.Param.
+func (p Parameter) GetParamConstructor() string {
+	constructor := fmt.Sprintf("config.NewParam(%s, %q, %t)", p.GetValueConstructor(), p.GetStyle(), p.GetExplode())
+	if p.JSONContent {
+		constructor += ".WithJSONContent()"
+	}
+	return constructor
+}
+
+// GetUsageSuffix returns the hints appended to the flag usage, telling that
+// the primitive values of a JSON content are sent JSON encoded.
+func (p Parameter) GetUsageSuffix() string {
+	if p.JSONContent && p.Type != JSONType {
+		return p.FlagValue.GetUsageSuffix() + " (sent as JSON)"
+	}
+	return p.FlagValue.GetUsageSuffix()
+}
+
+// GetGoMethodArgs renders the methods declaring the parameter as Go string arguments.
+func (p Parameter) GetGoMethodArgs() string {
+	methods := make([]string, 0, len(p.Methods))
+	for _, method := range p.Methods {
+		methods = append(methods, string(method))
+	}
+	return toGoArgs(methods)
 }
 
 func (p Parameter) GetSafeDescription() string {
 	return utils.RemoveBackTicks(p.Description)
 }
+
+// IsRequiredForAll reports whether every operation of the command requires the
+// parameter, in which case the flag itself can be marked as required.
+func (p Parameter) IsRequiredForAll(node *NodeCmd) bool {
+	return len(p.RequiredFor) > 0 && len(p.RequiredFor) == len(node.Methods)
+}
+
+// GetRequiredMarker returns the suffix appended to the flag usage of a
+// required parameter, naming the operations requiring it when not all do.
+func (p Parameter) GetRequiredMarker(node *NodeCmd) string {
+	if len(p.RequiredFor) == 0 {
+		return ""
+	}
+	if p.IsRequiredForAll(node) {
+		return " (required)"
+	}
+	methods := make([]string, 0, len(p.RequiredFor))
+	for _, method := range p.RequiredFor {
+		methods = append(methods, string(method))
+	}
+	return " (required for " + strings.Join(methods, ", ") + ")"
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestNewParameter(t *testing.T) {
+	tests := []struct {
+		name            string
+		param           *openapi3.Parameter
+		wantConstructor string
+		wantUsage       string
+	}{
+		{
+			name:            "schema",
+			param:           openapi3.NewQueryParameter("limit").WithSchema(openapi3.NewIntegerSchema().WithDefault(20)),
+			wantConstructor: `config.NewParam(config.NewTypedValue(config.IntegerValue, false).WithDefault("20"), "form", true)`,
+		},
+		{
+			name:            "large number default",
+			param:           openapi3.NewQueryParameter("max").WithSchema(openapi3.NewFloat64Schema().WithDefault(1e6).WithEnum(2.5e7, 0.5)),
+			wantConstructor: `config.NewParam(config.NewTypedValue(config.NumberValue, false).WithEnum("25000000", "0.5").WithDefault("1000000"), "form", true)`,
+			wantUsage:       " (one of: 25000000, 0.5)",
+		},
+		{
+			name:            "JSON content of a primitive",
+			param:           withContent(openapi3.NewQueryParameter("owner"), "application/json", openapi3.NewStringSchema().WithEnum("ann", "bob")),
+			wantConstructor: `config.NewParam(config.NewTypedValue(config.StringValue, false).WithEnum("ann", "bob"), "form", true).WithJSONContent()`,
+			wantUsage:       " (one of: ann, bob) (sent as JSON)",
+		},
+		{
+			name:            "JSON content of an object",
+			param:           withContent(openapi3.NewQueryParameter("filter"), "application/json", openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema())),
+			wantConstructor: `config.NewParam(config.NewTypedValue(config.JSONValue, false), "form", true).WithJSONContent()`,
+			wantUsage:       " (JSON)",
+		},
+		{
+			name:            "other content",
+			param:           withContent(openapi3.NewHeaderParameter("X-Data"), "text/plain", openapi3.NewStringSchema()),
+			wantConstructor: `config.NewParam(config.NewTypedValue(config.StringValue, false), "simple", false)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param := NewParameter(*tt.param)
+			if got := param.GetParamConstructor(); got != tt.wantConstructor {
+				t.Errorf("GetParamConstructor() =\n%s\nwant\n%s", got, tt.wantConstructor)
+			}
+			if got := param.GetUsageSuffix(); got != tt.wantUsage {
+				t.Errorf("GetUsageSuffix() = %q, want %q", got, tt.wantUsage)
+			}
+		})
+	}
+}
+
+func withContent(param *openapi3.Parameter, mediaType string, schema *openapi3.Schema) *openapi3.Parameter {
+	param.Content = openapi3.NewContentWithSchema(schema, []string{mediaType})
+	return param
+}
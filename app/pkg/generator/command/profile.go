@@ -0,0 +1,26 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GetEnvVarPrefix returns the prefix of the environment variables of the
+// generated CLI, e.g. DEVCLI for devcli.
+func (config CommandGlobalConfig) GetEnvVarPrefix() string {
+	return strings.ToUpper(strings.Trim(nonAlphanumeric.ReplaceAllString(config.RootUsage, "_"), "_"))
+}
+
+// HasConfigCommand reports whether the config command managing the profiles is
+// added to the node, being the root command of a spec not using this name.
+func (node *NodeCmd) HasConfigCommand() bool {
+	if !node.IsRootNodeCmd() {
+		return false
+	}
+	if _, exists := node.Children["config"]; exists {
+		log.Warn().Msg("The /config path conflicts with the config command, profiles can only be selected with --profile")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,212 @@
+package command
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// getSuccessResponse returns the first 2xx response declared by the
+// operation, by order of status code, falling back to the 2XX range.
+func getSuccessResponse(operation *openapi3.Operation) *openapi3.Response {
+	if operation == nil || operation.Responses == nil {
+		return nil
+	}
+	statuses := make([]string, 0, operation.Responses.Len())
+	for status := range operation.Responses.Map() {
+		if strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	// "2XX" sorts after the explicit status codes
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		if ref := operation.Responses.Value(status); ref != nil && ref.Value != nil {
+			return ref.Value
+		}
+	}
+	return nil
+}
+
+// getSuccessJSONSchema returns the JSON schema of the success response of the operation.
+func getSuccessJSONSchema(operation *openapi3.Operation) *openapi3.Schema {
+	response := getSuccessResponse(operation)
+	if response == nil {
+		return nil
+	}
+	for _, mediaType := range getSuccessMediaTypes(operation) {
+		if media := response.Content[mediaType]; isJSONMediaType(mediaType) && media != nil && media.Schema != nil {
+			return media.Schema.Value
+		}
+	}
+	return nil
+}
+
+// GetResponseContentTypes returns the media types of the success response of
+// each operation of the node, as []string literals.
+func (node *NodeCmd) GetResponseContentTypes() map[Method]string {
+	contentTypes := make(map[Method]string)
+	for method, operation := range node.Methods {
+		if mediaTypes := getSuccessMediaTypes(operation); len(mediaTypes) > 0 {
+			contentTypes[method] = toGoStringSlice(mediaTypes)
+		}
+	}
+	return contentTypes
+}
+
+// GetGoAcceptCompletions returns the success response media types of the
+// operations of the node as a []string literal, or "" unless one of them
+// declares several media types to choose from with --accept.
+func (node *NodeCmd) GetGoAcceptCompletions() string {
+	var mediaTypes []string
+	hasChoice := false
+	for _, method := range node.getSortedMethods() {
+		found := getSuccessMediaTypes(node.Methods[method])
+		hasChoice = hasChoice || len(found) > 1
+		for _, mediaType := range found {
+			if !slices.Contains(mediaTypes, mediaType) {
+				mediaTypes = append(mediaTypes, mediaType)
+			}
+		}
+	}
+	if !hasChoice {
+		return ""
+	}
+	sort.Strings(mediaTypes)
+	return toGoStringSlice(mediaTypes)
+}
+
+// streamMediaTypes are the media types of the responses streamed as events
+var streamMediaTypes = []string{
+	"text/event-stream",
+	"application/x-ndjson",
+	"application/ndjson",
+	"application/jsonl",
+	"application/x-jsonlines",
+	"application/json-seq",
+	"application/stream+json",
+}
+
+// HasStreamingResponse reports whether an operation of the node declares a
+// stream of events as its success response, e.g. Server-Sent Events.
+func (node *NodeCmd) HasStreamingResponse() bool {
+	for _, operation := range node.Methods {
+		for _, mediaType := range getSuccessMediaTypes(operation) {
+			if slices.Contains(streamMediaTypes, normalizeMediaType(mediaType)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getSuccessMediaTypes returns the media types of the success response of the operation, sorted.
+func getSuccessMediaTypes(operation *openapi3.Operation) []string {
+	response := getSuccessResponse(operation)
+	if response == nil {
+		return nil
+	}
+	mediaTypes := make([]string, 0, len(response.Content))
+	for mediaType := range response.Content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+	return mediaTypes
+}
+
+// isJSONMediaType reports whether the media type is application/json or a +json suffixed one.
+func isJSONMediaType(mediaType string) bool {
+	mediaType = normalizeMediaType(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// normalizeMediaType lowercases the media type and drops its parameters, e.g. "; charset=utf-8".
+func normalizeMediaType(mediaType string) string {
+	return strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+}
+
+// errorMessageFields are the properties of the error responses holding their message, by order of preference
+var errorMessageFields = []string{"detail", "message", "error_description", "title", "error", "errors", "description", "msg"}
+
+// GetErrorMessagePaths returns the JSON paths of the message of the error
+// responses declared by each operation of the node, as []string literals.
+func (node *NodeCmd) GetErrorMessagePaths() map[Method]string {
+	paths := make(map[Method]string)
+	for method, operation := range node.Methods {
+		if found := getErrorMessagePaths(operation); len(found) > 0 {
+			paths[method] = toGoStringSlice(found)
+		}
+	}
+	return paths
+}
+
+// getErrorMessagePaths looks for the message properties of the 4xx, 5xx and
+// default JSON responses, one level deep, e.g. "message", "error.message" or "errors[].message".
+func getErrorMessagePaths(operation *openapi3.Operation) []string {
+	if operation == nil || operation.Responses == nil {
+		return nil
+	}
+	statuses := make([]string, 0, operation.Responses.Len())
+	for status := range operation.Responses.Map() {
+		if strings.HasPrefix(status, "4") || strings.HasPrefix(status, "5") || status == "default" {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+
+	paths := []string{}
+	for _, status := range statuses {
+		ref := operation.Responses.Value(status)
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		mediaTypes := make([]string, 0, len(ref.Value.Content))
+		for mediaType := range ref.Value.Content {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+		sort.Strings(mediaTypes)
+		for _, mediaType := range mediaTypes {
+			media := ref.Value.Content[mediaType]
+			if !isJSONMediaType(mediaType) || media == nil || media.Schema == nil || media.Schema.Value == nil {
+				continue
+			}
+			for _, path := range findMessagePaths(media.Schema.Value, true) {
+				if !slices.Contains(paths, path) {
+					paths = append(paths, path)
+				}
+			}
+		}
+	}
+	return paths
+}
+
+func findMessagePaths(schema *openapi3.Schema, nested bool) []string {
+	properties, _ := collectProperties(schema)
+	paths := []string{}
+	for _, field := range errorMessageFields {
+		property := properties[field]
+		if property == nil || property.Value == nil {
+			continue
+		}
+		value := property.Value
+		switch {
+		case value.Type.Is("string"):
+			paths = append(paths, field)
+		case value.Type.Is("array") && value.Items != nil && value.Items.Value != nil:
+			if value.Items.Value.Type.Is("string") {
+				paths = append(paths, field+"[]")
+			} else if nested {
+				for _, path := range findMessagePaths(value.Items.Value, false) {
+					paths = append(paths, field+"[]."+path)
+				}
+			}
+		case nested:
+			for _, path := range findMessagePaths(value, false) {
+				paths = append(paths, field+"."+path)
+			}
+		}
+	}
+	return paths
+}
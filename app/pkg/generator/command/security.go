@@ -0,0 +1,293 @@
+package command
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/zerolog/log"
+)
+
+// SecurityFlagPrefix prefixes the flags holding the credentials of a security scheme
+const SecurityFlagPrefix = "auth-"
+
+// Security types supported by the generated CLI
+const (
+	APIKeySecurity = "apiKey"
+	BasicSecurity  = "basic"
+	BearerSecurity = "bearer"
+)
+
+var nonAlphanumeric = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// SecurityScheme is a security scheme of the spec the generated CLI can authenticate with.
+// OAuth2 and OpenID Connect schemes are sent as bearer tokens.
+type SecurityScheme struct {
+	Name        string
+	Type        string
+	Description string
+	// In and ParamName locate an API key in the request
+	In        string
+	ParamName string
+	// OAuth2 holds the flows supported by the login command, if any
+	OAuth2 *OAuth2Flows
+}
+
+// OAuth2Flows are the OAuth2 flows the generated login command can perform.
+type OAuth2Flows struct {
+	ClientCredentials *OAuth2Flow
+	AuthorizationCode *OAuth2Flow
+}
+
+type OAuth2Flow struct {
+	AuthorizationURL string
+	TokenURL         string
+	RefreshURL       string
+	Scopes           []string
+}
+
+// NewSecuritySchemes returns the supported security schemes of the spec, sorted by name.
+func NewSecuritySchemes(schemes openapi3.SecuritySchemes) []SecurityScheme {
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]SecurityScheme, 0, len(names))
+	for _, name := range names {
+		ref := schemes[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		scheme := SecurityScheme{Name: name, Description: ref.Value.Description}
+		switch ref.Value.Type {
+		case "apiKey":
+			scheme.Type = APIKeySecurity
+			scheme.In = ref.Value.In
+			scheme.ParamName = ref.Value.Name
+		case "http":
+			switch strings.ToLower(ref.Value.Scheme) {
+			case "basic":
+				scheme.Type = BasicSecurity
+			case "bearer":
+				scheme.Type = BearerSecurity
+			default:
+				log.Warn().Msgf("Security scheme %s uses the unsupported http scheme %s, it is ignored", name, ref.Value.Scheme)
+				continue
+			}
+		case "oauth2":
+			scheme.Type = BearerSecurity
+			scheme.OAuth2 = newOAuth2Flows(name, ref.Value.Flows)
+		case "openIdConnect":
+			scheme.Type = BearerSecurity
+		default:
+			log.Warn().Msgf("Security scheme %s has the unsupported type %s, it is ignored", name, ref.Value.Type)
+			continue
+		}
+		result = append(result, scheme)
+	}
+	return result
+}
+
+// newOAuth2Flows returns the client credentials and authorization code flows
+// of an OAuth2 scheme, or nil if it declares none of them.
+func newOAuth2Flows(name string, flows *openapi3.OAuthFlows) *OAuth2Flows {
+	if flows == nil || (flows.ClientCredentials == nil && flows.AuthorizationCode == nil) {
+		log.Debug().Msgf("Security scheme %s has no client credentials or authorization code flow, login is not supported", name)
+		return nil
+	}
+	return &OAuth2Flows{
+		ClientCredentials: newOAuth2Flow(flows.ClientCredentials),
+		AuthorizationCode: newOAuth2Flow(flows.AuthorizationCode),
+	}
+}
+
+func newOAuth2Flow(flow *openapi3.OAuthFlow) *OAuth2Flow {
+	if flow == nil {
+		return nil
+	}
+	scopes := make([]string, 0, len(flow.Scopes))
+	for scope := range flow.Scopes {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return &OAuth2Flow{
+		AuthorizationURL: flow.AuthorizationURL,
+		TokenURL:         flow.TokenURL,
+		RefreshURL:       flow.RefreshURL,
+		Scopes:           scopes,
+	}
+}
+
+// GetGoOAuth2 renders the OAuth2 flows of the scheme as a *config.OAuth2 literal.
+// URLs relative to the server are resolved against baseUrl.
+func (scheme SecurityScheme) GetGoOAuth2(baseUrl string) string {
+	if scheme.OAuth2 == nil {
+		return "nil"
+	}
+	flows := []string{}
+	for _, flow := range []struct {
+		name string
+		flow *OAuth2Flow
+	}{
+		{"ClientCredentials", scheme.OAuth2.ClientCredentials},
+		{"AuthorizationCode", scheme.OAuth2.AuthorizationCode},
+	} {
+		if flow.flow == nil {
+			continue
+		}
+		flows = append(flows, fmt.Sprintf(
+			"%s: &config.OAuth2Flow{AuthorizationURL: %q, TokenURL: %q, RefreshURL: %q, Scopes: %s}",
+			flow.name,
+			resolveURL(baseUrl, flow.flow.AuthorizationURL),
+			resolveURL(baseUrl, flow.flow.TokenURL),
+			resolveURL(baseUrl, flow.flow.RefreshURL),
+			toGoStringSlice(flow.flow.Scopes),
+		))
+	}
+	return "&config.OAuth2{" + strings.Join(flows, ", ") + "}"
+}
+
+func resolveURL(baseUrl string, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	base, err := url.Parse(baseUrl)
+	if err != nil {
+		return ref
+	}
+	resolved, err := base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return resolved.String()
+}
+
+// GetGoType returns the config constant of the scheme type.
+func (scheme SecurityScheme) GetGoType() string {
+	switch scheme.Type {
+	case APIKeySecurity:
+		return "config.APIKeySecurity"
+	case BasicSecurity:
+		return "config.BasicSecurity"
+	default:
+		return "config.BearerSecurity"
+	}
+}
+
+// GetOAuth2Schemes returns the schemes supporting the login command.
+func (config CommandGlobalConfig) GetOAuth2Schemes() []SecurityScheme {
+	schemes := []SecurityScheme{}
+	for _, scheme := range config.SecuritySchemes {
+		if scheme.OAuth2 != nil {
+			schemes = append(schemes, scheme)
+		}
+	}
+	return schemes
+}
+
+// HasLoginCommands reports whether the login and logout commands are added to the
+// node, being the root command of a spec with OAuth2 schemes not using these names.
+func (node *NodeCmd) HasLoginCommands() bool {
+	if !node.IsRootNodeCmd() || len(node.GlobalConfig.GetOAuth2Schemes()) == 0 {
+		return false
+	}
+	for _, name := range []string{"login", "logout"} {
+		if _, exists := node.Children[name]; exists {
+			log.Warn().Msgf("The /%s path conflicts with the %s command, OAuth2 login is not generated", name, name)
+			return false
+		}
+	}
+	return true
+}
+
+func (scheme SecurityScheme) GetFlagName() string {
+	return SecurityFlagPrefix + scheme.Name
+}
+
+// GetEnvVar returns the environment variable holding the credentials of the
+// scheme, e.g. DEVCLI_ACCESS_TOKEN for the access_token scheme of devcli.
+func (scheme SecurityScheme) GetEnvVar(rootUsage string) string {
+	name := nonAlphanumeric.ReplaceAllString(rootUsage+"_"+scheme.Name, "_")
+	return strings.ToUpper(strings.Trim(name, "_"))
+}
+
+func (scheme SecurityScheme) GetSafeDescription(rootUsage string) string {
+	var usage string
+	switch scheme.Type {
+	case APIKeySecurity:
+		usage = fmt.Sprintf("API key sent in the %s %s", scheme.ParamName, scheme.In)
+	case BasicSecurity:
+		usage = "Credentials for basic authentication, as username:password"
+	default:
+		usage = "Token for bearer authentication"
+	}
+	usage += fmt.Sprintf(" (%s security scheme, env %s)", scheme.Name, scheme.GetEnvVar(rootUsage))
+	if scheme.Description != "" {
+		usage = strings.TrimSuffix(scheme.Description, ".") + ". " + usage
+	}
+	return strings.ReplaceAll(usage, "`", "'")
+}
+
+// GetSecurity returns the security requirements of each operation of the node
+// as Go literals, operations without requirements being omitted.
+// Requirements of the operation take precedence over the ones of the spec,
+// an empty list meaning the operation is not authenticated.
+func (node *NodeCmd) GetSecurity() map[Method]string {
+	security := make(map[Method]string)
+	for method, operation := range node.Methods {
+		requirements := node.GlobalConfig.Security
+		if operation.Security != nil {
+			requirements = *operation.Security
+		}
+		if requirements == nil {
+			continue
+		}
+		security[method] = toGoSecurity(requirements)
+	}
+	return security
+}
+
+// NeedsBearerToken reports whether the bearer token may authenticate one of
+// the operations of the node, being sent by the operations without requirements
+// and used by the bearer schemes of their requirements.
+func (node *NodeCmd) NeedsBearerToken() bool {
+	for _, operation := range node.Methods {
+		requirements := node.GlobalConfig.Security
+		if operation.Security != nil {
+			requirements = *operation.Security
+		}
+		if requirements == nil {
+			return true
+		}
+		for _, requirement := range requirements {
+			for name := range requirement {
+				for _, scheme := range node.GlobalConfig.SecuritySchemes {
+					if scheme.Name == name && scheme.Type == BearerSecurity {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// toGoSecurity renders security requirements as a [][]string literal,
+// the scheme names of each requirement being sorted.
+func toGoSecurity(requirements openapi3.SecurityRequirements) string {
+	items := make([]string, 0, len(requirements))
+	for _, requirement := range requirements {
+		names := make([]string, 0, len(requirement))
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		items = append(items, "{"+toGoArgs(names)+"}")
+	}
+	return "[][]string{" + strings.Join(items, ", ") + "}"
+}
@@ -0,0 +1,165 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const securitySpec = `
+openapi: 3.0.0
+info: {title: devices, version: "1"}
+security:
+  - api_key: []
+paths:
+  /devices:
+    get:
+      responses:
+        "200": {description: ok}
+    post:
+      security:
+        - basic: []
+          api_key: []
+        - oauth: [write]
+      responses:
+        "201": {description: created}
+    delete:
+      security: []
+      responses:
+        "204": {description: deleted}
+components:
+  securitySchemes:
+    api_key: {type: apiKey, in: header, name: X-API-Key}
+    basic: {type: http, scheme: basic}
+    digest: {type: http, scheme: digest}
+    jwt: {type: http, scheme: Bearer}
+    mtls: {type: mutualTLS}
+    oidc: {type: openIdConnect, openIdConnectUrl: "https://example.com/.well-known/openid-configuration"}
+    oauth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: /oauth/token
+          scopes: {write: write devices, read: read devices}
+    implicit:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://example.com/authorize
+          scopes: {}
+`
+
+func TestNewSecuritySchemes(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(securitySpec))
+	if err != nil {
+		t.Fatalf("cannot load the spec: %v", err)
+	}
+
+	want := []SecurityScheme{
+		{Name: "api_key", Type: APIKeySecurity, In: "header", ParamName: "X-API-Key"},
+		{Name: "basic", Type: BasicSecurity},
+		{Name: "implicit", Type: BearerSecurity},
+		{Name: "jwt", Type: BearerSecurity},
+		{Name: "oauth", Type: BearerSecurity, OAuth2: &OAuth2Flows{
+			ClientCredentials: &OAuth2Flow{TokenURL: "/oauth/token", Scopes: []string{"read", "write"}},
+		}},
+		{Name: "oidc", Type: BearerSecurity},
+	}
+	if got := NewSecuritySchemes(doc.Components.SecuritySchemes); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewSecuritySchemes() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestGetGoOAuth2(t *testing.T) {
+	scheme := SecurityScheme{Name: "oauth", OAuth2: &OAuth2Flows{
+		ClientCredentials: &OAuth2Flow{TokenURL: "/oauth/token", Scopes: []string{"read"}},
+	}}
+	want := `&config.OAuth2{ClientCredentials: &config.OAuth2Flow{AuthorizationURL: "", TokenURL: "https://api.example.com/oauth/token", RefreshURL: "", Scopes: []string{"read"}}}`
+	if got := scheme.GetGoOAuth2("https://api.example.com/v1"); got != want {
+		t.Errorf("GetGoOAuth2() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGetSecurity(t *testing.T) {
+	root := newTestTree(t, securitySpec)
+	doc, _ := openapi3.NewLoader().LoadFromData([]byte(securitySpec))
+
+	tests := []struct {
+		name           string
+		globalSecurity openapi3.SecurityRequirements
+		want           map[Method]string
+	}{
+		{
+			name:           "requirements of the spec",
+			globalSecurity: doc.Security,
+			want: map[Method]string{
+				GET:    `[][]string{{"api_key"}}`,
+				POST:   `[][]string{{"api_key", "basic"}, {"oauth"}}`,
+				DELETE: `[][]string{}`,
+			},
+		},
+		{
+			name: "no requirements in the spec",
+			want: map[Method]string{
+				POST:   `[][]string{{"api_key", "basic"}, {"oauth"}}`,
+				DELETE: `[][]string{}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root.SetGlobalConfig(CommandGlobalConfig{Security: tt.globalSecurity})
+			if got := root.Children["devices"].GetSecurity(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetSecurity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsBearerToken(t *testing.T) {
+	doc, _ := openapi3.NewLoader().LoadFromData([]byte(securitySpec))
+	verbRoot := NewVerbTree(newTestTree(t, securitySpec))
+
+	tests := []struct {
+		name           string
+		globalSecurity openapi3.SecurityRequirements
+		verb           string
+		want           bool
+	}{
+		{name: "api key of the spec", globalSecurity: doc.Security, verb: "list", want: false},
+		{name: "no requirements", verb: "list", want: true},
+		{name: "oauth2 requirement", globalSecurity: doc.Security, verb: "create", want: true},
+		{name: "not authenticated", verb: "delete", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verbRoot.SetGlobalConfig(CommandGlobalConfig{
+				SecuritySchemes: NewSecuritySchemes(doc.Components.SecuritySchemes),
+				Security:        tt.globalSecurity,
+			})
+			if got := verbRoot.Children["devices"].Children[tt.verb].NeedsBearerToken(); got != tt.want {
+				t.Errorf("NeedsBearerToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetEnvVar(t *testing.T) {
+	tests := []struct {
+		rootUsage string
+		scheme    string
+		want      string
+	}{
+		{rootUsage: "devcli", scheme: "access_token", want: "DEVCLI_ACCESS_TOKEN"},
+		{rootUsage: "dev-cli", scheme: "api.key", want: "DEV_CLI_API_KEY"},
+		{rootUsage: "cli", scheme: "-bearer-", want: "CLI_BEARER"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scheme, func(t *testing.T) {
+			if got := (SecurityScheme{Name: tt.scheme}).GetEnvVar(tt.rootUsage); got != tt.want {
+				t.Errorf("GetEnvVar(%q) = %q, want %q", tt.rootUsage, got, tt.want)
+			}
+		})
+	}
+}
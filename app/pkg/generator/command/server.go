@@ -0,0 +1,128 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ServerNameExtension names a server, selectable with --server <name>.
+// Servers without it are named by their description.
+const ServerNameExtension = "x-oasnake-name"
+
+// Server is a server of the spec, its URL possibly holding {variables}.
+type Server struct {
+	URL       string
+	Name      string
+	Variables []ServerVariable
+}
+
+type ServerVariable struct {
+	Name    string
+	Default string
+	Enum    []string
+}
+
+// NewServers returns the servers of the spec, path item or operation, keeping their order.
+func NewServers(servers openapi3.Servers) []Server {
+	result := make([]Server, 0, len(servers))
+	for _, server := range servers {
+		if server == nil {
+			continue
+		}
+		result = append(result, Server{
+			URL:       strings.TrimSuffix(server.URL, "/"),
+			Name:      getServerName(server),
+			Variables: newServerVariables(server.Variables),
+		})
+	}
+	return result
+}
+
+func getServerName(server *openapi3.Server) string {
+	if raw, exists := server.Extensions[ServerNameExtension]; exists {
+		var name string
+		switch value := raw.(type) {
+		case string:
+			name = value
+		case json.RawMessage:
+			json.Unmarshal(value, &name)
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return server.Description
+}
+
+func newServerVariables(variables map[string]*openapi3.ServerVariable) []ServerVariable {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]ServerVariable, 0, len(names))
+	for _, name := range names {
+		variable := variables[name]
+		if variable == nil {
+			continue
+		}
+		result = append(result, ServerVariable{Name: name, Default: variable.Default, Enum: variable.Enum})
+	}
+	return result
+}
+
+// GetDefaultURL returns the URL of the server, its variables being replaced by their default value.
+func (server Server) GetDefaultURL() string {
+	url := server.URL
+	for _, variable := range server.Variables {
+		url = strings.ReplaceAll(url, "{"+variable.Name+"}", variable.Default)
+	}
+	return url
+}
+
+// GetDefaultServerURL returns the server URL used when none is selected at runtime.
+func (config CommandGlobalConfig) GetDefaultServerURL() string {
+	if config.BaseUrl != "" || len(config.Servers) == 0 {
+		return config.BaseUrl
+	}
+	return config.Servers[0].GetDefaultURL()
+}
+
+// GetGoServers renders the servers of the spec as a []config.Server literal.
+func (config CommandGlobalConfig) GetGoServers() string {
+	return toGoServers(config.Servers)
+}
+
+// GetServers returns the servers of each operation of the node declaring its
+// own ones, or whose path item does, as []config.Server literals.
+func (node *NodeCmd) GetServers() map[Method]string {
+	servers := make(map[Method]string)
+	for method, operation := range node.Methods {
+		if operation.Servers != nil && len(*operation.Servers) > 0 {
+			servers[method] = toGoServers(NewServers(*operation.Servers))
+		}
+	}
+	return servers
+}
+
+func toGoServers(servers []Server) string {
+	items := make([]string, 0, len(servers))
+	for _, server := range servers {
+		variables := make([]string, 0, len(server.Variables))
+		for _, variable := range server.Variables {
+			variables = append(variables, fmt.Sprintf("%q: {Default: %q, Enum: %s}", variable.Name, variable.Default, toGoStringSlice(variable.Enum)))
+		}
+		items = append(items, fmt.Sprintf(
+			"{URL: %q, Name: %q, Variables: map[string]config.ServerVariable{%s}}",
+			server.URL,
+			server.Name,
+			strings.Join(variables, ", "),
+		))
+	}
+	return "[]config.Server{" + strings.Join(items, ", ") + "}"
+}
@@ -0,0 +1,180 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// JSONType is the type of the flags taking a JSON document, e.g. for the
+// object parameters declared with a JSON content.
+const JSONType = "json"
+
+// FlagValue describes the typed value backing a generated flag,
+// rendered as a config.TypedValue in the generated code.
+type FlagValue struct {
+	Type     string
+	Format   string
+	IsArray  bool
+	IsObject bool
+	Enum     []string
+	Default  []string
+	// EnumDescriptions holds the description of each enum value, from the
+	// x-enum-descriptions vendor extension
+	EnumDescriptions []string
+}
+
+// enumDescriptionsExtensions lists the vendor extensions commonly used to
+// describe enum values, as an array parallel to enum.
+var enumDescriptionsExtensions = []string{"x-enum-descriptions", "x-enumDescriptions"}
+
+// newFlagValue derives the flag value from a schema. Arrays of primitives are
+// repeatable flags, objects with primitive values take repeated key=value
+// pairs. Anything else falls back to a raw string, in which case ok is false.
+func newFlagValue(schema *openapi3.Schema) (value FlagValue, ok bool) {
+	if schema == nil {
+		return FlagValue{Type: "string"}, false
+	}
+
+	items := schema
+	switch {
+	case schema.Type.Is("array") && schema.Items != nil && schema.Items.Value != nil:
+		value.IsArray = true
+		items = schema.Items.Value
+	case schema.Type.Is("object"):
+		value.IsObject = true
+		if ap := schema.AdditionalProperties.Schema; ap != nil && ap.Value != nil {
+			items = ap.Value
+		} else if len(schema.Properties) > 0 {
+			items = openapi3.NewStringSchema()
+		} else {
+			return FlagValue{Type: "string"}, false
+		}
+	}
+
+	value.Type = getPrimitiveType(items)
+	if value.Type == "" {
+		return FlagValue{Type: "string"}, false
+	}
+	if !value.IsObject {
+		value.Format = items.Format
+		for _, enum := range items.Enum {
+			value.Enum = append(value.Enum, toStringValue(enum))
+		}
+		value.EnumDescriptions = getEnumDescriptions(items)
+	}
+	value.Default = toStringValues(schema.Default)
+	return value, true
+}
+
+func getEnumDescriptions(schema *openapi3.Schema) []string {
+	for _, extension := range enumDescriptionsExtensions {
+		if descriptions, ok := schema.Extensions[extension].([]any); ok {
+			return toStringValues(descriptions)
+		}
+	}
+	return nil
+}
+
+func toStringValues(value any) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, toStringValue(item))
+		}
+		return values
+	case map[string]any:
+		values := make([]string, 0, len(v))
+		for key, item := range v {
+			values = append(values, key+"="+toStringValue(item))
+		}
+		sort.Strings(values)
+		return values
+	default:
+		return []string{toStringValue(v)}
+	}
+}
+
+// toStringValue formats a value of the spec as a flag value, numbers without
+// exponent, e.g. 1000000 rather than 1e+06.
+func toStringValue(value any) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+func (v FlagValue) IsBoolean() bool {
+	return v.Type == "boolean" && !v.IsArray && !v.IsObject
+}
+
+// GetValueType returns the generated config constant matching the value type.
+func (v FlagValue) GetValueType() string {
+	if v.IsObject {
+		return "config.ObjectValue"
+	}
+	switch v.Type {
+	case "integer":
+		return "config.IntegerValue"
+	case "number":
+		return "config.NumberValue"
+	case "boolean":
+		return "config.BooleanValue"
+	case JSONType:
+		return "config.JSONValue"
+	default:
+		return "config.StringValue"
+	}
+}
+
+// GetValueConstructor renders the Go expression building the config.TypedValue.
+func (v FlagValue) GetValueConstructor() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "config.NewTypedValue(%s, %t)", v.GetValueType(), v.IsArray)
+	if v.Format != "" {
+		fmt.Fprintf(&builder, ".WithFormat(%q)", v.Format)
+	}
+	if len(v.Enum) > 0 {
+		fmt.Fprintf(&builder, ".WithEnum(%s)", toGoArgs(v.Enum))
+	}
+	if len(v.Default) > 0 {
+		fmt.Fprintf(&builder, ".WithDefault(%s)", toGoArgs(v.Default))
+	}
+	return builder.String()
+}
+
+// GetUsageSuffix returns the hints appended to the flag usage.
+func (v FlagValue) GetUsageSuffix() string {
+	var suffix strings.Builder
+	if v.Type == JSONType {
+		suffix.WriteString(" (JSON)")
+	}
+	if len(v.Enum) > 0 {
+		suffix.WriteString(" (one of: " + strings.Join(v.Enum, ", ") + ")")
+	}
+	if v.IsObject {
+		suffix.WriteString(" (repeatable key=value)")
+	} else if v.IsArray {
+		suffix.WriteString(" (repeatable)")
+	}
+	return suffix.String()
+}
+
+// GetGoCompletions renders the shell completions of the enum values as a Go
+// string slice literal, each value followed by its description if any.
+func (v FlagValue) GetGoCompletions() string {
+	completions := make([]string, 0, len(v.Enum))
+	for i, enum := range v.Enum {
+		if i < len(v.EnumDescriptions) && v.EnumDescriptions[i] != "" {
+			enum += "\t" + strings.Join(strings.Fields(v.EnumDescriptions[i]), " ")
+		}
+		completions = append(completions, enum)
+	}
+	return toGoStringSlice(completions)
+}
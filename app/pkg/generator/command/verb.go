@@ -0,0 +1,305 @@
+package command
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/zerolog/log"
+)
+
+// itemVerbs and collectionVerbs name the operations of the paths ending with a
+// path param, e.g. /devices/{deviceId}, and of the paths listing them, e.g. /devices.
+var (
+	itemVerbs = map[Method]string{
+		GET:    "get",
+		PUT:    "replace",
+		PATCH:  "update",
+		DELETE: "delete",
+	}
+	collectionVerbs = map[Method]string{
+		GET:    "list",
+		POST:   "create",
+		PUT:    "replace",
+		PATCH:  "update",
+		DELETE: "delete",
+	}
+)
+
+// NewVerbTree returns the command tree of the verb layout, built from the
+// tree of the paths: the static segments of the paths are resource commands,
+// and each operation is a verb subcommand of the resource of its path, taking
+// the path params as positional arguments, e.g. "devices get <deviceId>" for
+// GET /devices/{deviceId}. The actions, e.g. /devices/search, and the sub-paths
+// of the items, e.g. /devices/{deviceId}/hard, are verbs of their parent resource.
+func NewVerbTree(root *NodeCmd) *NodeCmd {
+	verbRoot := NewRootNodeCmd()
+	// Resources are created first so that verbs never take their names
+	addResources(root, verbRoot)
+	addVerbs(root, verbRoot)
+	verbRoot.SetGlobalConfig(root.GlobalConfig)
+	return verbRoot
+}
+
+// addResources adds a resource command for each path node below the path node having one.
+func addResources(pathNode *NodeCmd, resource *NodeCmd) {
+	for _, segment := range pathNode.getSortedSegments() {
+		child := pathNode.Children[segment]
+		childResource := resource
+		if child.hasResource() {
+			if childResource = resource.Children[segment]; childResource == nil {
+				childResource = resource.NewChildrenNodeCmd(segment)
+				resource.Children[segment] = childResource
+				childResource.Summary = child.Summary
+				childResource.Description = child.Description
+			}
+		}
+		addResources(child, childResource)
+	}
+}
+
+// addVerbs adds the operations of the path node and of its descendants as
+// verb commands of their resource.
+func addVerbs(pathNode *NodeCmd, resource *NodeCmd) {
+	for _, method := range pathNode.getSortedMethods() {
+		name := resource.getAvailableVerb(pathNode, method)
+		verb := resource.NewChildrenNodeCmd(name)
+		verb.pathNode = pathNode
+		verb.Methods[method] = pathNode.Methods[method]
+		resource.Children[name] = verb
+	}
+	for _, segment := range pathNode.getSortedSegments() {
+		child := pathNode.Children[segment]
+		if child.hasResource() {
+			addVerbs(child, resource.Children[segment])
+		} else {
+			addVerbs(child, resource)
+		}
+	}
+}
+
+// hasResource reports whether the path node is a resource command in the verb
+// layout, being a static segment neither below a path param nor an action,
+// i.e. a path without GET operation nor paths below it, e.g. /devices/search.
+func (node *NodeCmd) hasResource() bool {
+	if node.IsRootNodeCmd() || node.IsParam() {
+		return false
+	}
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if parent.IsParam() {
+			return false
+		}
+	}
+	return node.IsCollection() || len(node.Children) > 0
+}
+
+// getAvailableVerb names the verb command of an operation after its method
+// on the path of the resource and of its items, POST being an action rather
+// than a creation on the paths that are not collections. The other operations
+// are named after their operationId in kebab case without the name of the
+// resource, e.g. search for searchDevices on POST /devices/search, or else
+// after the segments of their path below the resource, e.g. hard for DELETE
+// /devices/{deviceId}/hard. These names also name the operations whose verb
+// is already used by the resource, then the method.
+func (resource *NodeCmd) getAvailableVerb(pathNode *NodeCmd, method Method) string {
+	var verb string
+	var segments []string
+	for current := pathNode; current != nil && !current.hasResource() && !current.IsRootNodeCmd(); current = current.Parent {
+		if !current.IsParam() {
+			segments = append([]string{toKebabCase(current.segment)}, segments...)
+		}
+	}
+	if len(segments) == 0 {
+		verb = collectionVerbs[method]
+		if pathNode.IsParam() {
+			verb = itemVerbs[method]
+		} else if method == POST && !pathNode.IsRootNodeCmd() && !pathNode.IsCollection() {
+			verb = ""
+		}
+	}
+
+	candidates := []string{
+		verb,
+		trimResourceName(toKebabCase(pathNode.Methods[method].OperationID), toKebabCase(resource.segment)),
+		strings.Join(segments, "-"),
+		strings.ToLower(string(method)),
+	}
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		if _, exists := resource.Children[candidate]; !exists {
+			return candidate
+		}
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", strings.ToLower(string(method)), i)
+		if _, exists := resource.Children[candidate]; !exists {
+			log.Warn().Msgf("%s %s is generated as the %s command, the other names being used", method, pathNode.GetPath(), candidate)
+			return candidate
+		}
+	}
+}
+
+// trimResourceName removes the first occurrence of the kebab case name of the
+// resource, or of its singular, from a kebab case command name, e.g. devices
+// from search-devices and device from reload-device, unless nothing is left.
+func trimResourceName(name string, resourceName string) string {
+	if name == "" || resourceName == "" {
+		return name
+	}
+	for _, word := range []string{resourceName, toSingular(resourceName)} {
+		if before, after, found := strings.Cut("-"+name+"-", "-"+word+"-"); found && before+after != "" {
+			return strings.Trim(before+"-"+after, "-")
+		}
+	}
+	return name
+}
+
+// toSingular returns the singular of a plural English noun, e.g. device for
+// devices, policy for policies and class for classes, or the noun itself.
+func toSingular(noun string) string {
+	switch {
+	case strings.HasSuffix(noun, "ies"):
+		return strings.TrimSuffix(noun, "ies") + "y"
+	case strings.HasSuffix(noun, "sses"), strings.HasSuffix(noun, "xes"), strings.HasSuffix(noun, "ches"), strings.HasSuffix(noun, "shes"):
+		return strings.TrimSuffix(noun, "es")
+	case strings.HasSuffix(noun, "ss"):
+		return noun
+	default:
+		return strings.TrimSuffix(noun, "s")
+	}
+}
+
+// IsCollection reports whether the path lists items, having a GET operation
+// or paths of items below it. The other static paths are actions, e.g. /devices/search.
+func (node *NodeCmd) IsCollection() bool {
+	if _, exists := node.Methods[GET]; exists {
+		return true
+	}
+	for _, child := range node.Children {
+		if child.IsParam() {
+			return true
+		}
+	}
+	return false
+}
+
+// getVerbNames returns the names of the verb commands of a resource.
+func (node *NodeCmd) getVerbNames() []string {
+	names := []string{}
+	for _, segment := range node.getSortedSegments() {
+		if node.Children[segment].IsVerb() {
+			names = append(names, segment)
+		}
+	}
+	return names
+}
+
+// hasVerbs reports whether the node is a resource having verb commands, directly or in its sub-resources.
+func (node *NodeCmd) hasVerbs() bool {
+	for _, child := range node.Children {
+		if child.IsVerb() || child.hasVerbs() {
+			return true
+		}
+	}
+	return false
+}
+
+// getVerbsTag returns the tag shared by the operations of all the verbs of a
+// resource and of no other verb, or "", e.g. a tag of every operation of the
+// spec not describing any resource in particular.
+func (node *NodeCmd) getVerbsTag() string {
+	tag := ""
+	for _, name := range node.getVerbNames() {
+		tags := node.Children[name].getOperation().Tags
+		if len(tags) != 1 || (tag != "" && tags[0] != tag) {
+			return ""
+		}
+		tag = tags[0]
+	}
+	if tag == "" || node.getRoot().isTagUsedOutside(tag, node) {
+		return ""
+	}
+	return tag
+}
+
+// isTagUsedOutside reports whether the operation of a verb below the node,
+// but not of the resource, has the tag.
+func (node *NodeCmd) isTagUsedOutside(tag string, resource *NodeCmd) bool {
+	for _, child := range node.Children {
+		if child.IsVerb() && node != resource && slices.Contains(child.getOperation().Tags, tag) {
+			return true
+		}
+		if child.isTagUsedOutside(tag, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// getOperation returns the operation of a verb command.
+func (node *NodeCmd) getOperation() *openapi3.Operation {
+	return node.Methods[node.GetDefaultMethod()]
+}
+
+// IsVerb reports whether the node is the verb command of an operation, in the verb layout.
+func (node *NodeCmd) IsVerb() bool {
+	return node.pathNode != nil
+}
+
+// GetPathArgs returns the path params of a verb command, in the order of the
+// path, their values being given as positional arguments.
+func (node *NodeCmd) GetPathArgs() []*NodeCmd {
+	var args []*NodeCmd
+	for current := node.pathNode; current != nil; current = current.Parent {
+		if current.IsParam() {
+			args = append([]*NodeCmd{current}, args...)
+		}
+	}
+	return args
+}
+
+// GetGoPathArgNames returns the names of the path params of a verb command as a Go literal.
+func (node *NodeCmd) GetGoPathArgNames() string {
+	names := []string{}
+	for _, arg := range node.GetPathArgs() {
+		names = append(names, arg.GetParamName())
+	}
+	return toGoStringSlice(names)
+}
+
+func (node *NodeCmd) getSortedSegments() []string {
+	segments := make([]string, 0, len(node.Children))
+	for segment := range node.Children {
+		segments = append(segments, segment)
+	}
+	sort.Strings(segments)
+	return segments
+}
+
+// toKebabCase converts an operationId to a command name, e.g. getDeviceACLs to get-device-acls.
+func toKebabCase(s string) string {
+	runes := []rune(s)
+	var builder strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == ' ' || r == '.' || r == '-':
+			builder.WriteRune('-')
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			previous := runes[i-1]
+			// The s of a plural acronym does not start a word, e.g. ACLs
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1]) &&
+				(runes[i+1] != 's' || (i+2 < len(runes) && unicode.IsLower(runes[i+2])))
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				builder.WriteRune('-')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	return strings.Trim(builder.String(), "-")
+}
@@ -0,0 +1,178 @@
+package command
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const verbSpec = `
+openapi: 3.0.0
+info: {title: devices, version: "1"}
+tags:
+  - {name: devices, description: Manage the devices}
+  - {name: service, description: Every operation}
+paths:
+  /devices:
+    get:
+      tags: [devices]
+      responses: {"200": {description: ok}}
+    post:
+      tags: [devices]
+      responses: {"201": {description: created}}
+  /devices/search:
+    post:
+      tags: [devices]
+      operationId: searchDevices
+      responses: {"200": {description: ok}}
+  /devices/{deviceId}:
+    get:
+      tags: [devices]
+      responses: {"200": {description: ok}}
+    put:
+      tags: [devices]
+      responses: {"200": {description: ok}}
+    delete:
+      tags: [devices]
+      operationId: deleteDevice
+      responses: {"204": {description: deleted}}
+  /devices/{deviceId}/hard:
+    delete:
+      tags: [devices]
+      operationId: deleteDeviceHard
+      responses: {"204": {description: deleted}}
+  /devices/{deviceId}/acls/{aclId}:
+    delete:
+      tags: [devices]
+      responses: {"204": {description: deleted}}
+  /reload/{guid}:
+    post:
+      tags: [service]
+      operationId: reloadDevice
+      responses: {"204": {description: reloaded}}
+  /{guid}/bound:
+    summary: Binding of the devices
+    get:
+      tags: [service]
+      operationId: isDeviceBound
+      responses: {"200": {description: ok}}
+  /policies:
+    summary: Access policies
+    description: |
+      Policies granting access to the devices.
+      They are applied in order.
+    get:
+      tags: [service]
+      responses: {"200": {description: ok}}
+`
+
+// getCommandLines returns the usage lines of the verb commands below the node, e.g. "devices get <deviceId>".
+func getCommandLines(node *NodeCmd, prefix string) []string {
+	lines := []string{}
+	for _, child := range node.Children {
+		if child.IsVerb() {
+			lines = append(lines, prefix+child.GetUsage())
+		} else {
+			lines = append(lines, getCommandLines(child, prefix+child.segment+" ")...)
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func TestNewVerbTree(t *testing.T) {
+	verbRoot := NewVerbTree(newTestTree(t, verbSpec))
+
+	want := []string{
+		"devices create",
+		"devices delete <deviceId>",
+		"devices delete-hard <deviceId>",
+		"devices acls <deviceId> <aclId>",
+		"devices get <deviceId>",
+		"devices list",
+		"devices replace <deviceId>",
+		"devices search",
+		"is-device-bound <guid>",
+		"policies list",
+		"reload device <guid>",
+	}
+	sort.Strings(want)
+	if got := getCommandLines(verbRoot, ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewVerbTree() commands =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestGetResourceDescription(t *testing.T) {
+	doc, _ := openapi3.NewLoader().LoadFromData([]byte(verbSpec))
+	verbRoot := NewVerbTree(newTestTree(t, verbSpec))
+	verbRoot.SetGlobalConfig(CommandGlobalConfig{Tags: doc.Tags})
+
+	tests := []struct {
+		resource string
+		short    string
+		long     string
+	}{
+		{resource: "devices", short: "Manage the devices", long: "Manage the devices"},
+		{resource: "policies", short: "Access policies", long: "Policies granting access to the devices.\nThey are applied in order."},
+		{resource: "reload", short: "Operations on reload", long: "Operations on reload"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			resource := verbRoot.Children[tt.resource]
+			if got := resource.GetShortDescription(); got != tt.short {
+				t.Errorf("GetShortDescription() = %q, want %q", got, tt.short)
+			}
+			if got := resource.GetLongDescription(); got != tt.long {
+				t.Errorf("GetLongDescription() = %q, want %q", got, tt.long)
+			}
+		})
+	}
+}
+
+func TestTrimResourceName(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		want     string
+	}{
+		{name: "search-devices", resource: "devices", want: "search"},
+		{name: "reload-device", resource: "reload", want: "device"},
+		{name: "delete-device-hard", resource: "devices", want: "delete-hard"},
+		{name: "get-device-class", resource: "device-classes", want: "get"},
+		{name: "list-policies", resource: "policies", want: "list"},
+		{name: "devices", resource: "devices", want: "devices"},
+		{name: "get-devices-count", resource: "device", want: "get-devices-count"},
+		{name: "is-device-bound", resource: "", want: "is-device-bound"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trimResourceName(tt.name, tt.resource); got != tt.want {
+				t.Errorf("trimResourceName(%q, %q) = %q, want %q", tt.name, tt.resource, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToKebabCase(t *testing.T) {
+	tests := []struct {
+		operationID string
+		want        string
+	}{
+		{operationID: "getDeviceACLs", want: "get-device-acls"},
+		{operationID: "ACLsOfDevice", want: "acls-of-device"},
+		{operationID: "get_device.v2", want: "get-device-v2"},
+		{operationID: "listV2Devices", want: "list-v2-devices"},
+		{operationID: "_search_", want: "search"},
+		{operationID: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operationID, func(t *testing.T) {
+			if got := toKebabCase(tt.operationID); got != tt.want {
+				t.Errorf("toKebabCase(%q) = %q, want %q", tt.operationID, got, tt.want)
+			}
+		})
+	}
+}
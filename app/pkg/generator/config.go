@@ -9,6 +9,10 @@ type GeneratorConfig struct {
 	CommandName      string
 	WithModel        bool
 	WithCompilerFile bool
+	// DynamicCompletion makes the generated CLI complete path params by calling the list operation of their parent path
+	DynamicCompletion bool
+	// VerbCommands generates a subcommand per operation instead of a command per path, see command.NewVerbTree
+	VerbCommands bool
 
 	parserCodeGenConf *codegen.Configuration
 }
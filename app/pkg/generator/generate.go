@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"math/rand/v2"
@@ -16,6 +17,7 @@ import (
 
 const (
 	modelFileName = "model.go"
+	specFileName  = "spec.json"
 
 	// Default paths for project structure
 	appPath     = "/app"
@@ -41,10 +43,11 @@ func NewGenerator(cfg *GeneratorConfig) *Generator {
 //
 // The generation steps include:
 //  1. Loading the OpenAPI spec.
-//  2. Building a command tree from the API paths.
+//  2. Building a command tree from the API paths, or from their operations with VerbCommands.
 //  3. Rendering CLI command files.
 //  4. Generating models via oapi-codegen.
 //  5. Creating essential core application templates.
+//  6. Embedding the spec validating the requests.
 //
 // Returns an error if any stage of the process fails.
 func (g *Generator) Generate(rootCommand *command.NodeCmd, spec *openapi3.T) (string, error) {
@@ -55,6 +58,11 @@ func (g *Generator) Generate(rootCommand *command.NodeCmd, spec *openapi3.T) (st
 		return "", fmt.Errorf("failed to add generator config: %w", err)
 	}
 
+	// One subcommand per operation rather than one command per path
+	if g.Config.VerbCommands {
+		rootCommand = command.NewVerbTree(rootCommand)
+	}
+
 	// Render CLI command files recursively
 	cmdOutputPath := filepath.Join(g.Config.OutputDirectory, commandPath)
 	if err := traverseAndRenderCommands(rootCommand, cmdOutputPath); err != nil {
@@ -73,23 +81,39 @@ func (g *Generator) Generate(rootCommand *command.NodeCmd, spec *openapi3.T) (st
 		return "", fmt.Errorf("failed to generate core application templates: %w", err)
 	}
 
+	// Embed the spec validating the requests
+	if err := g.generateSpec(spec); err != nil {
+		return "", fmt.Errorf("failed to embed the OpenAPI spec: %w", err)
+	}
+
 	log.Info().Msgf("Code generation completed successfully. Output directory: %s", g.Config.OutputDirectory)
 	return g.GetEffectiveRootUsage(spec), nil
 }
 
 func (g *Generator) addRelevantGeneratorConfig(rootCommand *command.NodeCmd, spec *openapi3.T) error {
-	baseURL, err := g.GetEffectiveServerURL(spec)
-	if err != nil {
-		return err
+	// The generated CLI can still be used with --server <url> or a profile server
+	if _, err := g.GetEffectiveServerURL(spec); err != nil {
+		log.Warn().Msgf("%v, the generated CLI requires --server <url>", err)
+	}
+	var securitySchemes []command.SecurityScheme
+	if spec.Components != nil {
+		securitySchemes = command.NewSecuritySchemes(spec.Components.SecuritySchemes)
 	}
 	globalConfig := command.CommandGlobalConfig{
 		RootUsage:   g.GetEffectiveRootUsage(spec),
 		ModuleName:  g.Config.Module,
-		BaseUrl:     baseURL,
+		BaseUrl:     g.Config.ServerURL,
 		BaseCmdPath: g.resolvePath(commandPath),
 		ConfigPath:  g.resolvePath(configPath),
 		AppPath:     g.resolvePath(appPath),
 		ServicePath: g.resolvePath(servicePath),
+
+		DynamicCompletion: g.Config.DynamicCompletion,
+		SecuritySchemes:   securitySchemes,
+		Security:          spec.Security,
+		Servers:           command.NewServers(spec.Servers),
+		Requires:          getRequiredModules(),
+		Tags:              spec.Tags,
 	}
 	rootCommand.SetGlobalConfig(globalConfig)
 	return nil
@@ -138,13 +162,15 @@ func traverseAndRenderCommands(cmd *command.NodeCmd, dir string) error {
 	return nil
 }
 
-// GetEffectiveServerURL returns the effective server URL to be used,
+// GetEffectiveServerURL returns the server URL used by default by the generated CLI,
 // based on the configuration and the provided OpenAPI document.
 //
 // Priority order:
 // 1️⃣ If a server URL is explicitly defined in the config (`Config.ServerURL`), it is returned.
-// 2️⃣ Otherwise, it attempts to retrieve the base path from the OpenAPI `servers` section.
+// 2️⃣ Otherwise, the URL of the first server of the OpenAPI `servers` section is returned,
+// its variables being replaced by their default value.
 //
+// The other servers of the spec remain selectable at runtime with --server.
 // If neither is available, an error is returned.
 //
 // Parameters:
@@ -163,19 +189,14 @@ func (g *Generator) GetEffectiveServerURL(doc *openapi3.T) (string, error) {
 		return g.Config.ServerURL, nil
 	}
 
-	// 2️⃣ Attempt to extract the base path from OpenAPI spec
-	url, err := doc.Servers.BasePath()
-	if err != nil {
-		return "", fmt.Errorf("🚨 error extracting server URL from OpenAPI spec: %w", err)
-	}
-
-	// 3️⃣ Validate that the extracted URL is meaningful
-	if url == "/" {
-		return "", fmt.Errorf("❌ First server URL not defined in OpenAPI spec and no --server-url flag provided")
+	// 2️⃣ Use the first server of the OpenAPI spec
+	servers := command.NewServers(doc.Servers)
+	if len(servers) == 0 || servers[0].URL == "" {
+		return "", fmt.Errorf("❌ No server URL defined in the OpenAPI spec and no --server-url flag provided")
 	}
 
 	// ✅ Return the resolved server URL
-	return url, nil
+	return servers[0].GetDefaultURL(), nil
 }
 
 // GetEffectiveRootUsage determines the CLI binary name to use for generation.
@@ -223,6 +244,30 @@ func (g *Generator) generateModel(swagger *openapi3.T) error {
 	return nil
 }
 
+// generateSpec writes the spec, its references being internalized, to the
+// service package which embeds it to validate the requests before sending them.
+// It runs last since internalizing the references modifies the spec.
+func (g *Generator) generateSpec(spec *openapi3.T) error {
+	spec.InternalizeRefs(context.Background(), nil)
+	content, err := spec.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("error encoding the spec: %w", err)
+	}
+	if err := utils.WriteFileContent(
+		utils.WriterConfig{
+			OutputDirectoryShouldBeEmpty: false,
+			Output: utils.FS{
+				Directory: filepath.Join(g.Config.OutputDirectory, servicePath),
+				Filename:  specFileName,
+			},
+			Content: string(content),
+		},
+	); err != nil {
+		return fmt.Errorf("error writing the spec to file: %w", err)
+	}
+	return nil
+}
+
 // GenerateCoreApp generates the core application files based on the command tree.
 func (g *Generator) generateCoreApp(root *command.NodeCmd) error {
 	type fileGen struct {
@@ -237,10 +282,50 @@ func (g *Generator) generateCoreApp(root *command.NodeCmd) error {
 		{ConfigRequest, filepath.Join(g.Config.OutputDirectory, configPath), "resuest.go"},
 		{ConfigMethod, filepath.Join(g.Config.OutputDirectory, configPath), "method.go"},
 		{ConfigExtension, filepath.Join(g.Config.OutputDirectory, configPath), "extension.go"},
+		{ConfigBody, filepath.Join(g.Config.OutputDirectory, configPath), "body.go"},
+		{ConfigValue, filepath.Join(g.Config.OutputDirectory, configPath), "value.go"},
+		{ConfigParam, filepath.Join(g.Config.OutputDirectory, configPath), "param.go"},
+		{ConfigSecurity, filepath.Join(g.Config.OutputDirectory, configPath), "security.go"},
+		{ConfigProfile, filepath.Join(g.Config.OutputDirectory, configPath), "profile.go"},
+		{ConfigServer, filepath.Join(g.Config.OutputDirectory, configPath), "server.go"},
+		{ConfigOutput, filepath.Join(g.Config.OutputDirectory, configPath), "output.go"},
+		{ConfigPagination, filepath.Join(g.Config.OutputDirectory, configPath), "pagination.go"},
+		{ConfigRetry, filepath.Join(g.Config.OutputDirectory, configPath), "retry.go"},
+		{ConfigTransport, filepath.Join(g.Config.OutputDirectory, configPath), "transport.go"},
+		{ConfigForm, filepath.Join(g.Config.OutputDirectory, configPath), "form.go"},
+		{ConfigResponse, filepath.Join(g.Config.OutputDirectory, configPath), "response.go"},
+		{ConfigStream, filepath.Join(g.Config.OutputDirectory, configPath), "stream.go"},
 		{Service, filepath.Join(g.Config.OutputDirectory, servicePath), "service.go"},
+		{ServiceTest, filepath.Join(g.Config.OutputDirectory, servicePath), "service_test.go"},
+		{Completion, filepath.Join(g.Config.OutputDirectory, servicePath), "completion.go"},
+		{Output, filepath.Join(g.Config.OutputDirectory, servicePath), "output.go"},
+		{OutputTest, filepath.Join(g.Config.OutputDirectory, servicePath), "output_test.go"},
+		{Errors, filepath.Join(g.Config.OutputDirectory, servicePath), "errors.go"},
+		{Pagination, filepath.Join(g.Config.OutputDirectory, servicePath), "pagination.go"},
+		{PaginationTest, filepath.Join(g.Config.OutputDirectory, servicePath), "pagination_test.go"},
+		{Retry, filepath.Join(g.Config.OutputDirectory, servicePath), "retry.go"},
+		{RetryTest, filepath.Join(g.Config.OutputDirectory, servicePath), "retry_test.go"},
+		{Transport, filepath.Join(g.Config.OutputDirectory, servicePath), "transport.go"},
+		{TransportTest, filepath.Join(g.Config.OutputDirectory, servicePath), "transport_test.go"},
+		{Download, filepath.Join(g.Config.OutputDirectory, servicePath), "download.go"},
+		{DownloadTest, filepath.Join(g.Config.OutputDirectory, servicePath), "download_test.go"},
+		{Stream, filepath.Join(g.Config.OutputDirectory, servicePath), "stream.go"},
+		{StreamTest, filepath.Join(g.Config.OutputDirectory, servicePath), "stream_test.go"},
+		{Validate, filepath.Join(g.Config.OutputDirectory, servicePath), "validate.go"},
+		{ValidateTest, filepath.Join(g.Config.OutputDirectory, servicePath), "validate_test.go"},
+		{OAuth2, filepath.Join(g.Config.OutputDirectory, servicePath), "oauth2.go"},
+		{OAuth2Test, filepath.Join(g.Config.OutputDirectory, servicePath), "oauth2_test.go"},
 		{App, filepath.Join(g.Config.OutputDirectory, appPath), "app.go"},
 	}
 
+	if root.HasConfigCommand() {
+		files = append(files, fileGen{Profile, filepath.Join(g.Config.OutputDirectory, commandPath, command.CommonFolder), "profile.go"})
+	}
+
+	if root.HasLoginCommands() {
+		files = append(files, fileGen{Login, filepath.Join(g.Config.OutputDirectory, commandPath, command.CommonFolder), "login.go"})
+	}
+
 	if g.Config.WithCompilerFile {
 		files = append(files,
 			fileGen{Mod, g.Config.OutputDirectory, "go.mod"},
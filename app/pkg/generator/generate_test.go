@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/louislouislouislouis/oasnake/app/pkg/parser"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+// TestGenerate generates a CLI from the sample spec and runs its vet and
+// tests, the ones of the *_test.gotmpl templates included.
+func TestGenerate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("building the generated CLI is slow")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go command is needed to build the generated CLI")
+	}
+
+	tests := []struct {
+		name              string
+		verbCommands      bool
+		dynamicCompletion bool
+	}{
+		{name: "path commands"},
+		{name: "verb commands", verbCommands: true, dynamicCompletion: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := t.TempDir()
+			p := parser.NewParser(parser.Config{
+				ParserCodeGenConf: &codegen.Configuration{PackageName: "client"},
+				InputFilePath:     filepath.Join("..", "..", "..", "device-api.yaml"),
+			})
+			root, spec, err := p.ParseAndGetOpts()
+			if err != nil {
+				t.Fatalf("ParseAndGetOpts() error = %v", err)
+			}
+
+			cfg := NewGeneratorConfig(nil)
+			cfg.OutputDirectory = output
+			cfg.Module = "example.com/devices"
+			cfg.CommandName = "devices"
+			cfg.WithCompilerFile = true
+			cfg.VerbCommands = tt.verbCommands
+			cfg.DynamicCompletion = tt.dynamicCompletion
+			if _, err := NewGenerator(cfg).Generate(root, spec); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			for _, args := range [][]string{{"mod", "tidy"}, {"vet", "./..."}, {"test", "./..."}} {
+				cmd := exec.Command("go", args...)
+				cmd.Dir = output
+				if out, err := cmd.CombinedOutput(); err != nil {
+					t.Fatalf("go %v error = %v\n%s", args, err, out)
+				}
+			}
+		})
+	}
+}
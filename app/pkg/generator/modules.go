@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"runtime/debug"
+	"slices"
+
+	"github.com/louislouislouislouis/oasnake/app/pkg/generator/command"
+)
+
+// requiredModules are the modules the generated code is pinned to, the other
+// ones being resolved by go mod tidy. The versions are the ones the generator
+// is built with when it depends on the module as well, see getRequiredModules.
+var requiredModules = []command.Module{
+	{Path: "github.com/getkin/kin-openapi", Version: "v0.127.0", Purpose: "Validates the requests and responses against the embedded spec"},
+	{Path: "github.com/jmespath/go-jmespath", Version: "v0.4.0", Purpose: "Filters the responses with --query"},
+}
+
+// getRequiredModules returns the required modules, with the version of the
+// build of the generator for the ones it depends on, so that the generated
+// code uses the API the generator was written against.
+func getRequiredModules() []command.Module {
+	modules := slices.Clone(requiredModules)
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return modules
+	}
+	for i, module := range modules {
+		for _, dep := range info.Deps {
+			if dep.Replace != nil {
+				dep = dep.Replace
+			}
+			if dep.Path == module.Path && dep.Version != "" && dep.Version != "(devel)" {
+				modules[i].Version = dep.Version
+			}
+		}
+	}
+	return modules
+}
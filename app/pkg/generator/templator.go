@@ -30,6 +30,102 @@ var (
 	//go:embed assets/config/request.gotmpl
 	configRequest []byte
 
+	//go:embed assets/config/body.gotmpl
+	configBody []byte
+
+	//go:embed assets/config/value.gotmpl
+	configValue []byte
+
+	//go:embed assets/config/param.gotmpl
+	configParam []byte
+
+	//go:embed assets/config/security.gotmpl
+	configSecurity []byte
+
+	//go:embed assets/oauth2.gotmpl
+	oauth2Tmpl []byte
+
+	//go:embed assets/oauth2_test.gotmpl
+	oauth2TestTmpl []byte
+
+	//go:embed assets/login.gotmpl
+	loginTmpl []byte
+
+	//go:embed assets/config/profile.gotmpl
+	configProfile []byte
+
+	//go:embed assets/profile.gotmpl
+	profileTmpl []byte
+
+	//go:embed assets/config/server.gotmpl
+	configServer []byte
+
+	//go:embed assets/config/output.gotmpl
+	configOutput []byte
+
+	//go:embed assets/output.gotmpl
+	outputTmpl []byte
+
+	//go:embed assets/output_test.gotmpl
+	outputTest []byte
+
+	//go:embed assets/errors.gotmpl
+	errorsTmpl []byte
+
+	//go:embed assets/config/pagination.gotmpl
+	configPagination []byte
+
+	//go:embed assets/pagination.gotmpl
+	paginationTmpl []byte
+
+	//go:embed assets/pagination_test.gotmpl
+	paginationTest []byte
+
+	//go:embed assets/config/retry.gotmpl
+	configRetry []byte
+
+	//go:embed assets/retry.gotmpl
+	retryTmpl []byte
+
+	//go:embed assets/retry_test.gotmpl
+	retryTest []byte
+
+	//go:embed assets/config/transport.gotmpl
+	configTransport []byte
+
+	//go:embed assets/transport.gotmpl
+	transportTmpl []byte
+
+	//go:embed assets/transport_test.gotmpl
+	transportTest []byte
+
+	//go:embed assets/config/form.gotmpl
+	configForm []byte
+
+	//go:embed assets/config/response.gotmpl
+	configResponse []byte
+
+	//go:embed assets/download.gotmpl
+	downloadTmpl []byte
+
+	//go:embed assets/download_test.gotmpl
+	downloadTest []byte
+
+	//go:embed assets/config/stream.gotmpl
+	configStream []byte
+
+	//go:embed assets/stream.gotmpl
+	streamTmpl []byte
+
+	//go:embed assets/stream_test.gotmpl
+	streamTest []byte
+
+	//go:embed assets/validate.gotmpl
+	validateTmpl []byte
+
+	//go:embed assets/validate_test.gotmpl
+	validateTest []byte
+
 	//go:embed assets/main.gotmpl
 	mainTmpl []byte
 
@@ -38,6 +134,12 @@ var (
 
 	//go:embed assets/service.gotmpl
 	svcTmpl []byte
+
+	//go:embed assets/service_test.gotmpl
+	svcTestTmpl []byte
+
+	//go:embed assets/completion.gotmpl
+	completionTmpl []byte
 )
 
 type TemplatorType int
@@ -57,6 +159,40 @@ const (
 	ConfigExtension
 	ConfigCommand
 	CommonCommand
+	ConfigBody
+	ConfigValue
+	ConfigParam
+	ServiceTest
+	Completion
+	ConfigSecurity
+	OAuth2
+	OAuth2Test
+	Login
+	ConfigProfile
+	Profile
+	ConfigServer
+	ConfigOutput
+	Output
+	OutputTest
+	Errors
+	ConfigPagination
+	Pagination
+	PaginationTest
+	ConfigRetry
+	Retry
+	RetryTest
+	ConfigTransport
+	Transport
+	TransportTest
+	ConfigForm
+	ConfigResponse
+	Download
+	DownloadTest
+	ConfigStream
+	Stream
+	StreamTest
+	Validate
+	ValidateTest
 )
 
 func NewTemplator(t TemplatorType) *Templator {
@@ -85,6 +221,74 @@ func (t *Templator) getTemplate() string {
 		return string(configCommand)
 	case CommonCommand:
 		return string(commonCommandTmpl)
+	case ConfigBody:
+		return string(configBody)
+	case ConfigValue:
+		return string(configValue)
+	case ConfigParam:
+		return string(configParam)
+	case ServiceTest:
+		return string(svcTestTmpl)
+	case Completion:
+		return string(completionTmpl)
+	case ConfigSecurity:
+		return string(configSecurity)
+	case OAuth2:
+		return string(oauth2Tmpl)
+	case OAuth2Test:
+		return string(oauth2TestTmpl)
+	case Login:
+		return string(loginTmpl)
+	case ConfigProfile:
+		return string(configProfile)
+	case Profile:
+		return string(profileTmpl)
+	case ConfigServer:
+		return string(configServer)
+	case ConfigOutput:
+		return string(configOutput)
+	case Output:
+		return string(outputTmpl)
+	case OutputTest:
+		return string(outputTest)
+	case Errors:
+		return string(errorsTmpl)
+	case ConfigPagination:
+		return string(configPagination)
+	case Pagination:
+		return string(paginationTmpl)
+	case PaginationTest:
+		return string(paginationTest)
+	case ConfigRetry:
+		return string(configRetry)
+	case Retry:
+		return string(retryTmpl)
+	case RetryTest:
+		return string(retryTest)
+	case ConfigTransport:
+		return string(configTransport)
+	case Transport:
+		return string(transportTmpl)
+	case TransportTest:
+		return string(transportTest)
+	case ConfigForm:
+		return string(configForm)
+	case ConfigResponse:
+		return string(configResponse)
+	case Download:
+		return string(downloadTmpl)
+	case DownloadTest:
+		return string(downloadTest)
+	case ConfigStream:
+		return string(configStream)
+	case Stream:
+		return string(streamTmpl)
+	case StreamTest:
+		return string(streamTest)
+	case Validate:
+		return string(validateTmpl)
+	case ValidateTest:
+		return string(validateTest)
 	default:
 		return ""
 	}
@@ -76,20 +76,63 @@ func (p *Parser) toCommandTree(doc *openapi3.T) (*command.NodeCmd, error) {
 			}
 			current = current.Children[segment]
 		}
+		current.Summary = pathItem.Summary
+		current.Description = pathItem.Description
 
 		ops := map[command.Method]*openapi3.Operation{
-			command.GET:    pathItem.Get,
-			command.POST:   pathItem.Post,
-			command.PUT:    pathItem.Put,
-			command.PATCH:  pathItem.Patch,
-			command.DELETE: pathItem.Delete,
+			command.GET:     pathItem.Get,
+			command.POST:    pathItem.Post,
+			command.PUT:     pathItem.Put,
+			command.PATCH:   pathItem.Patch,
+			command.DELETE:  pathItem.Delete,
+			command.HEAD:    pathItem.Head,
+			command.OPTIONS: pathItem.Options,
+			command.TRACE:   pathItem.Trace,
 		}
 
 		for method, op := range ops {
 			if op != nil {
-				current.Methods[method] = op
+				current.Methods[method] = mergePathItemServers(mergePathItemParameters(op, pathItem.Parameters), pathItem.Servers)
 			}
 		}
 	}
 	return rootNode, nil
 }
+
+// mergePathItemParameters returns a copy of the operation holding the
+// parameters declared at the path item level as well as its own ones.
+// As defined by the OpenAPI specification, an operation parameter overrides a
+// path item parameter with the same name and location.
+// The original operation is left untouched as it is still used by the model generation.
+func mergePathItemParameters(op *openapi3.Operation, pathParameters openapi3.Parameters) *openapi3.Operation {
+	if len(pathParameters) == 0 {
+		return op
+	}
+
+	merged := *op
+	merged.Parameters = make(openapi3.Parameters, 0, len(pathParameters)+len(op.Parameters))
+	for _, pathParam := range pathParameters {
+		if pathParam == nil || pathParam.Value == nil {
+			continue
+		}
+		if op.Parameters.GetByInAndName(pathParam.Value.In, pathParam.Value.Name) != nil {
+			log.Debug().Msgf("Path parameter %s/%s is overridden by operation %s", pathParam.Value.In, pathParam.Value.Name, op.OperationID)
+			continue
+		}
+		merged.Parameters = append(merged.Parameters, pathParam)
+	}
+	merged.Parameters = append(merged.Parameters, op.Parameters...)
+	return &merged
+}
+
+// mergePathItemServers returns a copy of the operation holding the servers
+// declared at the path item level, unless it declares its own ones.
+func mergePathItemServers(op *openapi3.Operation, pathServers openapi3.Servers) *openapi3.Operation {
+	if len(pathServers) == 0 || op.Servers != nil {
+		return op
+	}
+
+	merged := *op
+	merged.Servers = &pathServers
+	return &merged
+}